@@ -0,0 +1,45 @@
+// Command remindercheck runs remindercheck analyzer as go vet tool.
+//
+// With "merge" subcommand it merges per-package inventories written with -remindercheck.export flag
+// into one repository-wide JSON report grouped by queue and task id:
+//
+//	go vet -vettool=$(which remindercheck) -remindercheck.export=/tmp/reminders ./...
+//	remindercheck merge /tmp/reminders > reminders.json
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"golang.yandex/linters/passes/remindercheck"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		if err := merge(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "remindercheck merge:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	unitchecker.Main(remindercheck.Analyzer())
+}
+
+func merge(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: remindercheck merge <export dir>")
+	}
+
+	report, err := remindercheck.MergeInventories(args[0])
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "\t")
+	return enc.Encode(report)
+}
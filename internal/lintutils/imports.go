@@ -0,0 +1,137 @@
+package lintutils
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ImportName returns local name under which package with given path is imported in file
+func ImportName(pass *analysis.Pass, file *ast.File, path string) (name string, found bool) {
+	for _, spec := range file.Imports {
+		specPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || specPath != path {
+			continue
+		}
+
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				continue
+			}
+			return spec.Name.Name, true
+		}
+
+		if pkgName, ok := pass.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+			return pkgName.Name(), true
+		}
+	}
+
+	return "", false
+}
+
+// AddImport returns edits that add import of given path to file.
+// If the package is already imported, no edits are returned and the existing local name is used.
+// Returns found=false if name is already taken in file scope by something else.
+func AddImport(pass *analysis.Pass, file *ast.File, path, name string) (localName string, edits []analysis.TextEdit, found bool) {
+	if existing, ok := ImportName(pass, file, path); ok {
+		return existing, nil, true
+	}
+
+	if scope := pass.TypesInfo.Scopes[file]; scope != nil {
+		if _, obj := scope.LookupParent(name, token.NoPos); obj != nil {
+			return "", nil, false
+		}
+	}
+
+	quoted := strconv.Quote(path)
+
+	// prefer to extend an existing parenthesized import block
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT || !genDecl.Rparen.IsValid() {
+			continue
+		}
+
+		// multi-line block is extended before closing paren
+		pos, text := genDecl.Rparen, "\t"+quoted+"\n"
+		// single-line block like import ("fmt") is extended after its last spec
+		if len(genDecl.Specs) > 0 {
+			last := genDecl.Specs[len(genDecl.Specs)-1].End()
+			if pass.Fset.Position(last).Line == pass.Fset.Position(genDecl.Rparen).Line {
+				pos, text = last, "\n\t"+quoted
+			}
+		}
+
+		return name, []analysis.TextEdit{{
+			Pos:     pos,
+			End:     pos,
+			NewText: []byte(text),
+		}}, true
+	}
+
+	// otherwise add a separate declaration after the last import or the package clause
+	pos := file.Name.End()
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			break
+		}
+		pos = genDecl.End()
+	}
+
+	return name, []analysis.TextEdit{{
+		Pos:     pos,
+		End:     pos,
+		NewText: []byte("\n\nimport " + quoted),
+	}}, true
+}
+
+// ImportUses returns number of references to package with given path in file
+func ImportUses(pass *analysis.Pass, file *ast.File, path string) int {
+	uses := 0
+	for ident, obj := range pass.TypesInfo.Uses {
+		pkgName, ok := obj.(*types.PkgName)
+		if !ok || pkgName.Imported().Path() != path {
+			continue
+		}
+		if ident.Pos() >= file.Pos() && ident.End() <= file.End() {
+			uses++
+		}
+	}
+	return uses
+}
+
+// DeleteImport returns edits that remove import of given path from file
+func DeleteImport(pass *analysis.Pass, file *ast.File, path string) []analysis.TextEdit {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			if specPath, err := strconv.Unquote(importSpec.Path.Value); err != nil || specPath != path {
+				continue
+			}
+
+			if len(genDecl.Specs) == 1 {
+				return []analysis.TextEdit{{Pos: genDecl.Pos(), End: genDecl.End()}}
+			}
+
+			// remove the whole line of import spec inside parenthesized block
+			tokFile := pass.Fset.File(importSpec.Pos())
+			line := tokFile.Line(importSpec.Pos())
+			start, end := tokFile.LineStart(line), importSpec.End()
+			if line < tokFile.LineCount() {
+				end = tokFile.LineStart(line + 1)
+			}
+			return []analysis.TextEdit{{Pos: start, End: end}}
+		}
+	}
+
+	return nil
+}
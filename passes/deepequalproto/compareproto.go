@@ -0,0 +1,151 @@
+package deepequalproto
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"golang.yandex/linters/internal/lintutils"
+)
+
+var CompareAnalyzer = &analysis.Analyzer{
+	Name: "compareproto",
+	Doc:  `compareproto checks that protobuf messages are not compared using == and != operators`,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+	},
+	Run: compareproto,
+}
+
+var (
+	compareFilter = []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+)
+
+func compareproto(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	ins.Preorder(compareFilter, func(n ast.Node) {
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			checkBinaryExpr(pass, node)
+		case *ast.SwitchStmt:
+			checkSwitchStmt(pass, node)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkBinaryExpr reports equality operators applied to proto messages
+func checkBinaryExpr(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+
+	if isNil(pass, expr.X) || isNil(pass, expr.Y) {
+		return
+	}
+
+	if !isProtoOperand(pass.TypesInfo.TypeOf(expr.X)) && !isProtoOperand(pass.TypesInfo.TypeOf(expr.Y)) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: fmt.Sprintf("avoid comparing proto.Message with %s; use %s.Equal instead", expr.Op, protoName),
+	}
+
+	x, okX := equalArg(pass, expr.X)
+	y, okY := equalArg(pass, expr.Y)
+	file, okFile := lintutils.FileOfPos(pass, expr.Pos())
+	if !okX || !okY || !okFile {
+		pass.Report(diag)
+		return
+	}
+
+	if name, edits, ok := lintutils.AddImport(pass, file, protoPath, protoName); ok {
+		negation := ""
+		if expr.Op == token.NEQ {
+			negation = "!"
+		}
+
+		edits = append(edits, analysis.TextEdit{
+			Pos:     expr.Pos(),
+			End:     expr.End(),
+			NewText: []byte(fmt.Sprintf("%s%s.Equal(%s, %s)", negation, name, x, y)),
+		})
+
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace with " + protoName + ".Equal",
+			TextEdits: edits,
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+// checkSwitchStmt reports switch statements which compare proto message tag with case values
+func checkSwitchStmt(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if stmt.Tag == nil || !isProtoOperand(pass.TypesInfo.TypeOf(stmt.Tag)) {
+		return
+	}
+
+	for _, s := range stmt.Body.List {
+		clause := s.(*ast.CaseClause)
+		for _, value := range clause.List {
+			if isNil(pass, value) {
+				continue
+			}
+
+			pass.ReportRangef(value, "avoid comparing proto.Message in switch statement; use %s.Equal instead", protoName)
+		}
+	}
+}
+
+// equalArg returns source of proto.Equal argument for given operand.
+// Dereferenced message pointers are passed as is.
+func equalArg(pass *analysis.Pass, operand ast.Expr) (string, bool) {
+	operand = ast.Unparen(operand)
+	if star, ok := operand.(*ast.StarExpr); ok && isMessage(pass.TypesInfo.TypeOf(star.X)) {
+		operand = star.X
+	}
+
+	if !isMessage(pass.TypesInfo.TypeOf(operand)) {
+		return "", false
+	}
+
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, pass.Fset, operand); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// isProtoOperand reports whether typ is a proto message, pointer to it or proto.Message interface
+func isProtoOperand(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	typ = types.Unalias(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = types.Unalias(ptr.Elem())
+	}
+
+	return isProtoType(typ) || isProtoType(typ.Underlying())
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	return pass.TypesInfo.Types[expr].IsNil()
+}
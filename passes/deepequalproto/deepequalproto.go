@@ -1,13 +1,32 @@
 package deepequalproto
 
 import (
+	"fmt"
 	"go/ast"
 	"go/types"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 	"golang.org/x/tools/go/types/typeutil"
+
+	"golang.yandex/linters/internal/lintutils"
+)
+
+const (
+	reflectPath      = "reflect"
+	reflectDeepEqual = reflectPath + ".DeepEqual"
+
+	protoPath = "google.golang.org/protobuf/proto"
+	protoName = "proto"
+
+	protocmpPath      = "google.golang.org/protobuf/testing/protocmp"
+	protocmpName      = "protocmp"
+	protocmpTransform = protocmpPath + ".Transform"
+
+	cmpPath       = "github.com/google/go-cmp/cmp"
+	cmpPathPrefix = "github.com/google/go-cmp/"
 )
 
 type compareFn struct {
@@ -54,6 +73,12 @@ var comparingFn = map[string]compareFn{
 	},
 }
 
+// cmpFn lists go-cmp functions which require protocmp.Transform option to compare proto messages
+var cmpFn = map[string]bool{
+	"github.com/google/go-cmp/cmp.Diff":  true,
+	"github.com/google/go-cmp/cmp.Equal": true,
+}
+
 var Analyzer = &analysis.Analyzer{
 	Name: "deepequalproto",
 	Doc:  `deepequalproto checks that protobuf messages are not compared using reflect.DeepEqual`,
@@ -80,6 +105,11 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
+		if cmpFn[fn.FullName()] {
+			checkCmpCall(pass, call, fn)
+			return
+		}
+
 		compareFn, ok := comparingFn[fn.FullName()]
 		if !ok {
 			return
@@ -89,6 +119,11 @@ func run(pass *analysis.Pass) (any, error) {
 			shortName := fn.Pkg().Name() + "." + fn.Name()
 
 			if hasProto(pass, call.Args[i]) {
+				if fn.FullName() == reflectDeepEqual {
+					checkDeepEqualCall(pass, call, shortName)
+					return
+				}
+
 				pass.ReportRangef(call, "avoid using %s with proto.Message; use %s instead",
 					shortName,
 					compareFn.alternativeName)
@@ -100,6 +135,149 @@ func run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
+// checkDeepEqualCall reports reflect.DeepEqual comparison of proto messages.
+// Comparison of two message pointers is rewritten to proto.Equal,
+// collections of messages, message values and structs holding messages are pointed to protocmp.
+func checkDeepEqualCall(pass *analysis.Pass, call *ast.CallExpr, shortName string) {
+	const argsCount = 2
+	if len(call.Args) != argsCount {
+		return
+	}
+
+	x, y := pass.TypesInfo.TypeOf(call.Args[0]), pass.TypesInfo.TypeOf(call.Args[1])
+	// proto.Equal accepts message pointers only, not message values or structs holding messages
+	if isProtoCollection(x) || isProtoCollection(y) || isProtoStruct(x) || isProtoStruct(y) {
+		pass.ReportRangef(call, "avoid using %s with proto.Message; use cmp.Equal with %s.Transform() instead",
+			shortName,
+			protocmpName)
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("avoid using %s with proto.Message; use %s.Equal instead", shortName, protoName),
+	}
+
+	file, ok := lintutils.FileOfPos(pass, call.Pos())
+	if !ok || !isMessage(x) || !isMessage(y) {
+		pass.Report(diag)
+		return
+	}
+
+	if name, edits, ok := lintutils.AddImport(pass, file, protoPath, protoName); ok {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     call.Fun.Pos(),
+			End:     call.Fun.End(),
+			NewText: []byte(name + ".Equal"),
+		})
+
+		// drop reflect import if this call was its only use
+		if lintutils.ImportUses(pass, file, reflectPath) == 1 {
+			edits = append(edits, lintutils.DeleteImport(pass, file, reflectPath)...)
+		}
+
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace with " + protoName + ".Equal",
+			TextEdits: edits,
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+// checkCmpCall reports go-cmp comparison of proto messages without protocmp.Transform option
+func checkCmpCall(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	const optsOffset = 2
+
+	if len(call.Args) < optsOffset || call.Ellipsis.IsValid() {
+		// options are spread from slice, we cannot reason about them
+		return
+	}
+
+	if !hasProto(pass, call.Args[0]) && !hasProto(pass, call.Args[1]) {
+		return
+	}
+
+	for _, opt := range call.Args[optsOffset:] {
+		if mayHaveProtoTransform(pass, opt) {
+			return
+		}
+	}
+
+	shortName := fn.Pkg().Name() + "." + fn.Name()
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("avoid using %s with proto.Message without %s.Transform() option", shortName, protocmpName),
+	}
+
+	if file, ok := lintutils.FileOfPos(pass, call.Pos()); ok {
+		if name, edits, ok := lintutils.AddImport(pass, file, protocmpPath, protocmpName); ok {
+			lastArg := call.Args[len(call.Args)-1]
+			edits = append(edits, analysis.TextEdit{
+				Pos:     lastArg.End(),
+				End:     lastArg.End(),
+				NewText: []byte(", " + name + ".Transform()"),
+			})
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Add " + protocmpName + ".Transform() option",
+				TextEdits: edits,
+			}}
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// mayHaveProtoTransform reports whether go-cmp option expression may contain protocmp.Transform.
+// Options which can not be resolved statically, e.g. results of helper functions, are assumed to contain it.
+func mayHaveProtoTransform(pass *analysis.Pass, opt ast.Expr) bool {
+	switch opt := ast.Unparen(opt).(type) {
+	case *ast.CallExpr:
+		fn, ok := typeutil.Callee(pass.TypesInfo, opt).(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return true
+		}
+
+		switch {
+		case fn.FullName() == protocmpTransform:
+			return true
+		case fn.Pkg().Path() == protocmpPath:
+			// other protocmp options are applied on top of Transform and don't include it
+			return false
+		case strings.HasPrefix(fn.Pkg().Path(), cmpPathPrefix):
+			// go-cmp options may wrap other options, e.g. cmp.FilterPath(f, protocmp.Transform())
+			for _, arg := range opt.Args {
+				if isCmpOption(pass.TypesInfo.TypeOf(arg)) && mayHaveProtoTransform(pass, arg) {
+					return true
+				}
+			}
+			return false
+		default:
+			return true
+		}
+	case *ast.CompositeLit:
+		for _, elt := range opt.Elts {
+			if mayHaveProtoTransform(pass, elt) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// isCmpOption reports whether typ is go-cmp option or list of options
+func isCmpOption(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == cmpPath && (named.Obj().Name() == "Option" || named.Obj().Name() == "Options")
+}
+
 // hasProto reports whether the type of v contains the proto message.
 // See containsProto, below, for the meaning of "contains".
 func hasProto(pass *analysis.Pass, v ast.Expr) bool {
@@ -110,18 +288,68 @@ func hasProto(pass *analysis.Pass, v ast.Expr) bool {
 	return containsProto(tv.Type)
 }
 
+// isProtoType reports whether typ is a proto message itself.
+// Legacy (APIv1) messages are detected by XXX_unrecognized field,
+// modern (APIv2) ones by ProtoReflect method with pointer receiver.
 func isProtoType(typ types.Type) bool {
-	if t, ok := typ.(*types.Struct); ok {
+	switch t := typ.(type) {
+	case *types.Struct:
 		for i := 0; i < t.NumFields(); i++ {
 			if t.Field(i).Name() == "XXX_unrecognized" {
 				return true
 			}
 		}
+	case *types.Named:
+		if _, isPtr := t.Underlying().(*types.Pointer); !isPtr {
+			return hasProtoReflect(types.NewPointer(t))
+		}
+	case *types.Interface:
+		return hasProtoReflect(t)
 	}
 
 	return false
 }
 
+// isMessage reports whether typ implements APIv2 proto.Message interface,
+// i.e. is a message pointer or proto.Message interface itself
+func isMessage(typ types.Type) bool {
+	return typ != nil && hasProtoReflect(typ)
+}
+
+func hasProtoReflect(typ types.Type) bool {
+	sel := types.NewMethodSet(typ).Lookup(nil, "ProtoReflect")
+	if sel == nil {
+		return false
+	}
+
+	sig := sel.Type().(*types.Signature)
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1
+}
+
+// isProtoCollection reports whether typ is a slice, array or map which contains proto messages
+func isProtoCollection(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		return containsProto(typ)
+	}
+
+	return false
+}
+
+// isProtoStruct reports whether typ is a message value or a struct which contains proto messages
+func isProtoStruct(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	_, ok := typ.Underlying().(*types.Struct)
+	return ok && containsProto(typ)
+}
+
 func containsProto(typ types.Type) bool {
 	// Track types being processed, to avoid infinite recursion.
 	// Using types as keys here is OK because we are checking for the identical pointer, not
@@ -130,6 +358,7 @@ func containsProto(typ types.Type) bool {
 
 	var check func(t types.Type) bool
 	check = func(t types.Type) bool {
+		t = types.Unalias(t)
 		if isProtoType(t) {
 			return true
 		}
@@ -0,0 +1,29 @@
+package a
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func cmpDiff(t *testing.T, want, got *Message) {
+	if diff := cmp.Diff(want, got); diff != "" { // want `avoid using cmp.Diff with proto.Message without protocmp.Transform\(\) option`
+		t.Error(diff)
+	}
+}
+
+func cmpEqual(want, got []*Message) bool {
+	return cmp.Equal(want, got, cmp.AllowUnexported(Message{})) // want `avoid using cmp.Equal with proto.Message without protocmp.Transform\(\) option`
+}
+
+func cmpPlain(want, got Plain) bool {
+	return cmp.Equal(want, got)
+}
+
+func cmpSpreadOptions(want, got *Message, opts []cmp.Option) bool {
+	return cmp.Equal(want, got, opts...)
+}
+
+func cmpOptionVar(want, got *Message, opt cmp.Option) bool {
+	return cmp.Equal(want, got, opt)
+}
@@ -0,0 +1,15 @@
+package a
+
+import ("github.com/google/go-cmp/cmp")
+
+func cmpOpts() cmp.Option {
+	return cmp.Options{}
+}
+
+func cmpHelperOptions(want, got *Message) bool {
+	return cmp.Equal(want, got, cmpOpts())
+}
+
+func cmpAllowUnexported(want, got *Message) bool {
+	return cmp.Equal(want, got, cmp.AllowUnexported(Message{})) // want `avoid using cmp.Equal with proto.Message without protocmp.Transform\(\) option`
+}
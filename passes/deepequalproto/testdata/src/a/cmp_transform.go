@@ -0,0 +1,21 @@
+package a
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func cmpTransform(want, got *Message) bool {
+	return cmp.Equal(want, got, protocmp.Transform())
+}
+
+func cmpTransformOptions(want, got map[string]*Message) string {
+	return cmp.Diff(want, got, cmp.Options{cmp.AllowUnexported(), protocmp.Transform()})
+}
+
+func cmpTransformMultiline(want, got *Message) string {
+	return cmp.Diff( // want `avoid using cmp.Diff with proto.Message without protocmp.Transform\(\) option`
+		want,
+		got,
+	)
+}
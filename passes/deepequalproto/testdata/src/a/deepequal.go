@@ -0,0 +1,43 @@
+package a
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func deepEqualV2(x, y *MessageV2) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use proto.Equal instead`
+}
+
+func deepEqualInterface(x, y proto.Message) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use proto.Equal instead`
+}
+
+func deepEqualLegacy(x, y *Message) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use proto.Equal instead`
+}
+
+func deepEqualValue(x, y MessageV2) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use cmp.Equal with protocmp.Transform\(\) instead`
+}
+
+func deepEqualWrapper(x, y Wrapper) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use cmp.Equal with protocmp.Transform\(\) instead`
+}
+
+func deepEqualSlice(x, y []*MessageV2) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use cmp.Equal with protocmp.Transform\(\) instead`
+}
+
+func deepEqualMap(x, y map[string]*MessageV2) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use cmp.Equal with protocmp.Transform\(\) instead`
+}
+
+func deepEqualPlain(x, y *Plain) bool {
+	return reflect.DeepEqual(x, y)
+}
+
+func deepEqualLegacyValue(x, y Message) bool {
+	return reflect.DeepEqual(x, y) // want `avoid using reflect.DeepEqual with proto.Message; use cmp.Equal with protocmp.Transform\(\) instead`
+}
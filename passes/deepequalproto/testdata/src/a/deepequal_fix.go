@@ -0,0 +1,10 @@
+package a
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func deepEqualOnlyUse(x, y *MessageV2) {
+	fmt.Println(reflect.DeepEqual(x, y)) // want `avoid using reflect.DeepEqual with proto.Message; use proto.Equal instead`
+}
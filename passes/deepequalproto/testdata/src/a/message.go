@@ -0,0 +1,27 @@
+package a
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+type Message struct {
+	Name string
+
+	XXX_unrecognized []byte
+}
+
+type Plain struct {
+	Name string
+}
+
+type MessageV2 struct {
+	state int
+
+	Name string
+}
+
+func (*MessageV2) ProtoReflect() protoreflect.Message {
+	panic("not implemented")
+}
+
+type Wrapper struct {
+	Msg *MessageV2
+}
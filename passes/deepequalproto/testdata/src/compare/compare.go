@@ -0,0 +1,49 @@
+package compare
+
+import (
+	"a"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func comparePointers(x, y *a.MessageV2) bool {
+	return x == y // want `avoid comparing proto.Message with ==; use proto.Equal instead`
+}
+
+func compareInterfaces(x, y proto.Message) bool {
+	return x != y // want `avoid comparing proto.Message with !=; use proto.Equal instead`
+}
+
+func compareValues(x, y *a.MessageV2) bool {
+	return *x == *y // want `avoid comparing proto.Message with ==; use proto.Equal instead`
+}
+
+func compareLegacy(x, y *a.Message) bool {
+	return x == y // want `avoid comparing proto.Message with ==; use proto.Equal instead`
+}
+
+func compareNil(x *a.MessageV2, y proto.Message) bool {
+	return x == nil || nil != y
+}
+
+func comparePlain(x, y *a.Plain) bool {
+	return x == y
+}
+
+func compareSwitch(x, y, z *a.MessageV2) int {
+	switch x {
+	case nil:
+		return 0
+	case y: // want `avoid comparing proto.Message in switch statement; use proto.Equal instead`
+		return 1
+	case z: // want `avoid comparing proto.Message in switch statement; use proto.Equal instead`
+		return 2
+	}
+
+	switch {
+	case x == nil:
+		return 3
+	}
+
+	return -1
+}
@@ -0,0 +1,9 @@
+package protoreflect
+
+type ProtoMessage interface {
+	ProtoReflect() Message
+}
+
+type Message interface {
+	Interface() ProtoMessage
+}
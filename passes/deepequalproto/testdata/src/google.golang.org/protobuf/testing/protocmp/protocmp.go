@@ -0,0 +1,7 @@
+package protocmp
+
+import "github.com/google/go-cmp/cmp"
+
+func Transform(...any) cmp.Option {
+	panic("not implemented")
+}
@@ -0,0 +1,65 @@
+package execinquery
+
+import (
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// StringsAnalyzer collects values of package-level strings and shares exported ones with dependent packages.
+// Analyzers resolving query strings require it, so StringsFact is declared by a single analyzer
+// and all of them can be registered in one checker.
+var StringsAnalyzer = &analysis.Analyzer{
+	Name:       "constantstrings",
+	Doc:        `constantstrings collects values of package-level string constants and variables, which are never modified`,
+	Run:        constantStrings,
+	FactTypes:  []analysis.Fact{new(StringsFact)},
+	ResultType: reflect.TypeFor[*resolver](),
+}
+
+// StringsFact is a package fact, which carries values of exported string constants
+// and exported package-level string variables, which are never reassigned
+type StringsFact struct {
+	Values map[string]string
+}
+
+func (*StringsFact) AFact() {}
+
+func (f *StringsFact) String() string {
+	return "strings(" + strings.Join(slices.Sorted(maps.Keys(f.Values)), ", ") + ")"
+}
+
+func constantStrings(pass *analysis.Pass) (any, error) {
+	globals := collectGlobals(pass)
+	exportStringsFact(pass, globals.values)
+	return globals, nil
+}
+
+// importedStrings returns values of exported strings of imported packages
+func importedStrings(pass *analysis.Pass) map[*types.Package]map[string]string {
+	imported := make(map[*types.Package]map[string]string)
+	for _, f := range pass.AllPackageFacts() {
+		if fact, ok := f.Fact.(*StringsFact); ok && f.Package != pass.Pkg {
+			imported[f.Package] = fact.Values
+		}
+	}
+	return imported
+}
+
+// exportStringsFact exports values of exported package-level strings to dependent packages
+func exportStringsFact(pass *analysis.Pass, globals map[types.Object]string) {
+	fact := &StringsFact{Values: make(map[string]string)}
+	for obj, v := range globals {
+		if obj.Exported() && obj.Parent() == pass.Pkg.Scope() {
+			fact.Values[obj.Name()] = v
+		}
+	}
+
+	if len(fact.Values) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+}
@@ -0,0 +1,115 @@
+package execinquery
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// dialect describes SQL dialect specific lexical rules and keywords
+type dialect struct {
+	name string
+
+	// rowCommands are commands which return rows
+	rowCommands []string
+	// returning reports whether INSERT/UPDATE/DELETE may return rows with RETURNING clause
+	returning bool
+
+	hashComments        bool // # starts a comment till the end of line
+	backslashEscapes    bool // backslash escapes quotes in string literals
+	doubleQuotedStrings bool // "..." is a string literal, not an identifier
+	backtickIdents      bool // `...` is a quoted identifier
+	bracketIdents       bool // [...] is a quoted identifier
+	dollarQuotes        bool // $tag$...$tag$ is a string literal
+	atVariables         bool // @name is a variable, not a placeholder
+	numberedParams      bool // only $N are placeholders, ? is an operator (e.g. jsonb key exists)
+}
+
+var (
+	dialectGeneric = &dialect{
+		rowCommands:    []string{"SELECT", "VALUES", "SHOW", "TABLE", "EXPLAIN", "DESCRIBE", "DESC", "PRAGMA", "CALL", "EXISTS", "FETCH"},
+		returning:      true,
+		backtickIdents: true,
+		dollarQuotes:   true,
+	}
+
+	dialectPostgres = &dialect{
+		name:           "postgres",
+		rowCommands:    []string{"SELECT", "VALUES", "SHOW", "TABLE", "EXPLAIN", "FETCH"},
+		returning:      true,
+		dollarQuotes:   true,
+		numberedParams: true,
+	}
+
+	dialectMySQL = &dialect{
+		name:                "mysql",
+		rowCommands:         []string{"SELECT", "VALUES", "SHOW", "TABLE", "EXPLAIN", "DESCRIBE", "DESC", "CALL"},
+		hashComments:        true,
+		backslashEscapes:    true,
+		doubleQuotedStrings: true,
+		backtickIdents:      true,
+		atVariables:         true,
+	}
+
+	dialectClickHouse = &dialect{
+		name:             "clickhouse",
+		rowCommands:      []string{"SELECT", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "EXISTS", "CHECK"},
+		hashComments:     true,
+		backslashEscapes: true,
+		backtickIdents:   true,
+	}
+
+	dialectSQLite = &dialect{
+		name:           "sqlite",
+		rowCommands:    []string{"SELECT", "VALUES", "EXPLAIN", "PRAGMA"},
+		returning:      true,
+		backtickIdents: true,
+		bracketIdents:  true,
+	}
+
+	dialects = []*dialect{dialectPostgres, dialectMySQL, dialectClickHouse, dialectSQLite}
+)
+
+func (d *dialect) isRowCommand(cmd string) bool {
+	return slices.Contains(d.rowCommands, strings.ToUpper(cmd))
+}
+
+// dialectFlag is a flag.Value of SQL dialect
+type dialectFlag struct {
+	dialect *dialect
+}
+
+func (f *dialectFlag) Set(v string) error {
+	if v == "" {
+		f.dialect = nil
+		return nil
+	}
+
+	for _, d := range dialects {
+		if d.name == v {
+			f.dialect = d
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(dialects))
+	for _, d := range dialects {
+		names = append(names, d.name)
+	}
+	return fmt.Errorf("unknown SQL dialect %q, expected one of: %s", v, strings.Join(names, ", "))
+}
+
+func (f *dialectFlag) String() string {
+	if f == nil || f.dialect == nil {
+		return ""
+	}
+	return f.dialect.name
+}
+
+// get returns chosen dialect or generic one, which accepts syntax of all of them
+func (f *dialectFlag) get() *dialect {
+	if f.dialect == nil {
+		return dialectGeneric
+	}
+	return f.dialect
+}
@@ -2,11 +2,6 @@ package execinquery
 
 import (
 	"go/ast"
-	"go/token"
-	"maps"
-	"regexp"
-	"strconv"
-	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -15,9 +10,18 @@ import (
 
 const doc = "execinquery is a linter about query string checker in Query function which reads your Go src files and warning it finds"
 
+func init() {
+	Analyzer.Flags.Var(&flagMethods, "methods",
+		"comma-separated list of additional query methods in form pkg/path.Type.Method:N, where N is index of query argument")
+	Analyzer.Flags.Var(&flagDialect, "dialect",
+		"SQL dialect of queries: postgres, mysql, clickhouse, sqlite; accepts syntax of all of them if empty")
+}
+
+// flags are owned by each analyzer, so analyzers of this package
+// can be configured independently when run by a single checker
 var (
-	commentExp          = regexp.MustCompile(`--[^\n]*\n`)
-	multilineCommentExp = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	flagMethods methodsFlag
+	flagDialect dialectFlag
 )
 
 // Analyzer is checking database/sql pkg Query's function
@@ -27,224 +31,107 @@ var Analyzer = &analysis.Analyzer{
 	Run:  run,
 	Requires: []*analysis.Analyzer{
 		inspect.Analyzer,
+		StringsAnalyzer,
 	},
 }
 
 func run(pass *analysis.Pass) (any, error) {
+	methods := methodTables{query: queryMethods(flagMethods), exec: execMethods}
+	dialect := flagDialect.get()
+
+	inspectQueryCalls(pass, func(callExpr *ast.CallExpr, r *resolver) {
+		inspectCallExpr(pass, callExpr, methods.query, dialect, r)
+		inspectExecCallExpr(pass, callExpr, methods.exec, dialect, r)
+	})
+
+	return nil, nil
+}
+
+// inspectQueryCalls calls fn for each call expression of top-level functions/methods
+// with resolver, which tracks values of strings at the point of call
+func inspectQueryCalls(pass *analysis.Pass, fn func(callExpr *ast.CallExpr, r *resolver)) {
 	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	// collect global vars for package
-	globalVars := collectGlobalVars(ins)
+	// package-level strings are collected once and shared by analyzers of this package
+	globals := pass.ResultOf[StringsAnalyzer].(*resolver)
 
 	// inspect each individual top-level function/method
 	funcFilter := []ast.Node{
 		(*ast.FuncDecl)(nil),
 	}
 	ins.Preorder(funcFilter, func(n ast.Node) {
-		funcDecl := n.(*ast.FuncDecl)
-		inspectFunc(pass, funcDecl, maps.Clone(globalVars))
-	})
-
-	return nil, nil
-}
-
-func collectGlobalVars(ins *inspector.Inspector) map[string]ast.Expr {
-	filter := []ast.Node{
-		(*ast.GenDecl)(nil),
-	}
-
-	vars := make(map[string]ast.Expr)
-	ins.Preorder(filter, func(n ast.Node) {
-		decl := n.(*ast.GenDecl)
-		if decl.Tok != token.VAR && decl.Tok != token.CONST {
-			return
-		}
+		r := newResolver(pass, globals)
+		for n := range ast.Preorder(n) {
+			r.visit(n)
 
-		for _, spec := range decl.Specs {
-			valueSpec := spec.(*ast.ValueSpec)
-			for i, ident := range valueSpec.Names {
-				if i >= len(valueSpec.Values) {
-					break
-				}
-				vars[ident.Name] = valueSpec.Values[i]
+			if callExpr, ok := n.(*ast.CallExpr); ok {
+				fn(callExpr, r)
 			}
 		}
 	})
-
-	return vars
-}
-
-func inspectFunc(pass *analysis.Pass, funcDecl *ast.FuncDecl, vars map[string]ast.Expr) {
-	for n := range ast.Preorder(funcDecl) {
-		switch node := n.(type) {
-		case *ast.AssignStmt:
-			vars = collectAssignmentVariables(node, vars)
-		case *ast.CallExpr:
-			inspectCallExpr(pass, node, vars)
-		}
-	}
 }
 
-func collectAssignmentVariables(assn *ast.AssignStmt, vars map[string]ast.Expr) map[string]ast.Expr {
-	for i, lhs := range assn.Lhs {
-		ident, ok := lhs.(*ast.Ident)
-		if !ok || i >= len(assn.Rhs) {
-			continue
-		}
-
-		if assn.Tok == token.ADD_ASSIGN {
-			vars[ident.Name] = concatExpr(vars[ident.Name], assn.Rhs[i])
-		} else {
-			vars[ident.Name] = assn.Rhs[i]
-		}
+func inspectCallExpr(pass *analysis.Pass, callExpr *ast.CallExpr, methods methodTable, d *dialect, r *resolver) {
+	if pass.TypesInfo == nil {
+		return
 	}
 
-	return vars
-}
-
-func concatExpr(target, addition ast.Expr) ast.Expr {
-	targetLit, ok := target.(*ast.BasicLit)
+	method, ok := lookupMethod(pass, callExpr, methods)
 	if !ok {
-		return target
-	}
-	additionLit, ok := addition.(*ast.BasicLit)
-	if !ok {
-		return target
-	}
-
-	if targetLit.Kind != token.STRING {
-		return target
-	}
-
-	return &ast.BasicLit{
-		ValuePos: targetLit.ValuePos,
-		Kind:     targetLit.Kind,
-		Value:    strconv.Quote(trimLitQuotes(targetLit.Value) + trimLitQuotes(additionLit.Value)),
-	}
-}
-
-func inspectCallExpr(pass *analysis.Pass, callExpr *ast.CallExpr, vars map[string]ast.Expr) {
-	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
-	if !ok || !strings.Contains(selector.Sel.Name, "Query") {
 		return
 	}
 
-	if pass.TypesInfo == nil {
+	query, ok := queryArgString(callExpr, method, r)
+	if !ok {
 		return
 	}
 
-	selectorInfo := pass.TypesInfo.Uses[selector.Sel]
-	if selectorInfo == nil || selectorInfo.Pkg() == nil || selectorInfo.Pkg().Path() != "database/sql" {
+	stmt, ok := parseStatement(query, d)
+	if !ok || stmt.returnsRows(d) {
 		return
 	}
 
-	replacement := "Exec"
-	paramIdx := 0 // the index of the query argument
-	if strings.Contains(selector.Sel.Name, "Context") {
-		replacement = "ExecContext"
-		paramIdx = 1
-	}
+	pass.Reportf(callExpr.Fun.Pos(), "Use %s instead of %s to execute `%s` query", method.replacement(), method.Name, stmt.cmd.text)
+}
 
-	if len(callExpr.Args)-1 < paramIdx {
+// inspectExecCallExpr reports Exec calls with queries, which only read and return rows
+func inspectExecCallExpr(pass *analysis.Pass, callExpr *ast.CallExpr, methods methodTable, d *dialect, r *resolver) {
+	if pass.TypesInfo == nil {
 		return
 	}
 
-	query := getQueryString(callExpr.Args[paramIdx], vars)
-	if query == "" {
+	method, ok := lookupMethod(pass, callExpr, methods)
+	if !ok {
 		return
 	}
 
-	query = strings.TrimSpace(cleanValue(query))
-
-	cmd := extractCmd(query)
-	if strings.EqualFold(cmd, "SELECT") || strings.EqualFold(cmd, "SHOW") {
+	query, ok := queryArgString(callExpr, method, r)
+	if !ok {
 		return
 	}
 
-	// PostgreSQL RETURNING clause makes INSERT/UPDATE/DELETE return rows
-	upperQuery := strings.ToUpper(query)
-	if strings.Contains(upperQuery, "RETURNING") {
+	stmt, ok := parseStatement(query, d)
+	if !ok || !stmt.cmd.is("SELECT") && !stmt.cmd.is("SHOW") && !stmt.cmd.is("VALUES") || !stmt.readOnly() {
 		return
 	}
 
-	pass.Reportf(callExpr.Fun.Pos(), "Use %s instead of %s to execute `%s` query", replacement, selector.Sel.Name, cmd)
+	pass.Reportf(callExpr.Fun.Pos(), "Use %s instead of %s to execute `%s` query", method.replacement(), method.Name, stmt.cmd.text)
 }
 
-func getQueryString(exp any, vars map[string]ast.Expr) string {
-	switch e := exp.(type) {
-	case *ast.AssignStmt:
-		var b strings.Builder
-		for _, stmt := range e.Rhs {
-			b.WriteString(cleanValue(getQueryString(stmt, vars)))
-		}
-		return b.String()
-
-	case *ast.BasicLit:
-		return e.Value
-
-	case *ast.ValueSpec:
-		var b strings.Builder
-		for _, value := range e.Values {
-			b.WriteString(cleanValue(getQueryString(value, vars)))
+// queryArgString returns query string passed to method call.
+// For prepared statements query is taken from preparation call of receiver.
+func queryArgString(callExpr *ast.CallExpr, method Method, r *resolver) (string, bool) {
+	if method.QueryArg == preparedQuery {
+		recv := recvExpr(callExpr)
+		if recv == nil {
+			return "", false
 		}
-		return b.String()
-
-	case *ast.Ident:
-		if assn, ok := vars[e.Name]; ok && assn != nil {
-			return getQueryString(assn, vars)
-		}
-		// Fall back to original declaration
-		if e.Obj == nil {
-			return ""
-		}
-		return getQueryString(e.Obj.Decl, vars)
-
-	case *ast.BinaryExpr:
-		v := cleanValue(getQueryString(e.X, vars))
-		v += cleanValue(getQueryString(e.Y, vars))
-		return v
+		return r.resolve(recv)
 	}
 
-	return ""
-}
-
-func extractCmd(query string) string {
-	start, end := -1, len(query)
-	for i, r := range query {
-		if start == -1 && (r > ' ' && r < '~') {
-			start = i
-			continue
-		}
-		if start != -1 && (r <= ' ' || r >= '~') {
-			end = i
-			break
-		}
+	if len(callExpr.Args)-1 < method.QueryArg {
+		return "", false
 	}
-	if start == -1 {
-		return ""
-	}
-	return query[start:end]
-}
-
-func cleanValue(s string) string {
-	v := strings.NewReplacer(`"`, "", "`", "").Replace(s)
-	v = multilineCommentExp.ReplaceAllString(v, "")
-	return commentExp.ReplaceAllString(v, "")
-}
 
-func trimLitQuotes(value string) string {
-	if value == "" {
-		return value
-	}
-
-	switch value[0] {
-	case '"':
-		return strings.TrimPrefix(strings.TrimSuffix(value, `"`), `"`)
-	case '\'':
-		return strings.TrimPrefix(strings.TrimSuffix(value, `'`), `'`)
-	case '`':
-		return strings.TrimPrefix(strings.TrimSuffix(value, "`"), "`")
-	default:
-		return value
-	}
+	return r.resolve(callExpr.Args[method.QueryArg])
 }
@@ -3,6 +3,8 @@ package execinquery_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 
 	"golang.yandex/linters/passes/execinquery"
@@ -11,5 +13,97 @@ import (
 // TestAnalyzer is a test for Analyzer.
 func TestAnalyzer(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, execinquery.Analyzer, "a")
+	analysistest.Run(t, testdata, execinquery.Analyzer, "a", "b", "lexer", "resolve")
+}
+
+// TestStringsAnalyzer is a test for facts of StringsAnalyzer.
+func TestStringsAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.StringsAnalyzer, "queries")
+}
+
+// TestValidate checks that all analyzers of the package can be registered in one checker.
+func TestValidate(t *testing.T) {
+	require.NoError(t, analysis.Validate([]*analysis.Analyzer{
+		execinquery.Analyzer,
+		execinquery.PlaceholdersAnalyzer,
+		execinquery.InjectionAnalyzer,
+		execinquery.RowsCloseAnalyzer,
+		execinquery.StringsAnalyzer,
+	}))
+}
+
+// TestDialect is a test for Analyzer with dialect specific keywords.
+func TestDialect(t *testing.T) {
+	require.Error(t, execinquery.Analyzer.Flags.Set("dialect", "oracle"))
+	require.NoError(t, execinquery.Analyzer.Flags.Set("dialect", "mysql"))
+	defer func() {
+		require.NoError(t, execinquery.Analyzer.Flags.Set("dialect", ""))
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.Analyzer, "mysql")
+}
+
+// TestCustomMethods is a test for Analyzer with user defined query methods.
+func TestCustomMethods(t *testing.T) {
+	require.Error(t, execinquery.Analyzer.Flags.Set("methods", "c.Storage.Fetch"))
+	require.Error(t, execinquery.Analyzer.Flags.Set("methods", "Fetch:1"))
+	require.NoError(t, execinquery.Analyzer.Flags.Set("methods", "c.Storage.Fetch:1"))
+	defer func() {
+		require.NoError(t, execinquery.Analyzer.Flags.Set("methods", ""))
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.Analyzer, "c")
+}
+
+// TestFlagsIndependent checks that analyzers of the package don't share flag values.
+func TestFlagsIndependent(t *testing.T) {
+	require.NoError(t, execinquery.Analyzer.Flags.Set("methods", "c.Storage.Fetch:1"))
+	defer func() {
+		require.NoError(t, execinquery.Analyzer.Flags.Set("methods", ""))
+	}()
+
+	for _, a := range []*analysis.Analyzer{
+		execinquery.PlaceholdersAnalyzer,
+		execinquery.InjectionAnalyzer,
+		execinquery.RowsCloseAnalyzer,
+	} {
+		require.Empty(t, a.Flags.Lookup("methods").Value.String(), a.Name)
+	}
+}
+
+// TestPlaceholdersAnalyzer is a test for PlaceholdersAnalyzer.
+func TestPlaceholdersAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.PlaceholdersAnalyzer, "placeholders")
+}
+
+// TestPlaceholdersDialect is a test for PlaceholdersAnalyzer with PostgreSQL dialect.
+func TestPlaceholdersDialect(t *testing.T) {
+	require.NoError(t, execinquery.PlaceholdersAnalyzer.Flags.Set("dialect", "postgres"))
+	defer func() {
+		require.NoError(t, execinquery.PlaceholdersAnalyzer.Flags.Set("dialect", ""))
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.PlaceholdersAnalyzer, "placeholderspg")
+}
+
+// TestInjectionAnalyzer is a test for InjectionAnalyzer.
+func TestInjectionAnalyzer(t *testing.T) {
+	require.NoError(t, execinquery.InjectionAnalyzer.Flags.Set("quote-funcs", "injection.quoteIdent"))
+	defer func() {
+		require.NoError(t, execinquery.InjectionAnalyzer.Flags.Set("quote-funcs", ""))
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.InjectionAnalyzer, "injection")
+}
+
+// TestRowsCloseAnalyzer is a test for RowsCloseAnalyzer.
+func TestRowsCloseAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, execinquery.RowsCloseAnalyzer, "rowsclose")
 }
@@ -0,0 +1,501 @@
+package execinquery
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// defaultQuoteFuncs are functions, which make values safe to be embedded into query
+var defaultQuoteFuncs = []string{
+	"github.com/lib/pq.QuoteIdentifier",
+	"github.com/lib/pq.QuoteLiteral",
+	"(github.com/jackc/pgx/v5.Identifier).Sanitize",
+}
+
+// formatFuncs are functions, which produce strings without quotes and spaces
+var formatFuncs = []string{
+	"strconv.Itoa",
+	"strconv.FormatInt",
+	"strconv.FormatUint",
+	"strconv.FormatFloat",
+	"strconv.FormatBool",
+}
+
+var (
+	injectionMethods methodsFlag
+	flagQuoteFuncs   funcsFlag
+)
+
+// funcsFlag is a flag.Value of comma-separated function names.
+// Repeated flags extend the list, empty value resets it.
+type funcsFlag []string
+
+func (f *funcsFlag) Set(v string) error {
+	if strings.TrimSpace(v) == "" {
+		*f = nil
+		return nil
+	}
+
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*f = append(*f, name)
+		}
+	}
+	return nil
+}
+
+func (f *funcsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(*f, ",")
+}
+
+func init() {
+	InjectionAnalyzer.Flags.Var(&injectionMethods, "methods",
+		"comma-separated list of additional query methods in form pkg/path.Type.Method:N, where N is index of query argument")
+	InjectionAnalyzer.Flags.Var(&flagQuoteFuncs, "quote-funcs",
+		"comma-separated list of additional quoting functions in form pkg/path.Func or (pkg/path.Type).Method")
+}
+
+// InjectionAnalyzer reports SQL queries built from non-constant data
+var InjectionAnalyzer = &analysis.Analyzer{
+	Name: "sqlinjection",
+	Doc:  `sqlinjection checks that SQL queries passed to database methods are not built from non-constant data`,
+	Run:  sqlinjection,
+	Requires: []*analysis.Analyzer{
+		buildssa.Analyzer,
+	},
+}
+
+func sqlinjection(pass *analysis.Pass) (any, error) {
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	tables := []methodTable{queryMethods(injectionMethods), execMethods, prepareMethods}
+	quoteFuncs := slices.Concat(defaultQuoteFuncs, formatFuncs, flagQuoteFuncs)
+
+	for _, fn := range ssaInput.SrcFuncs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+
+				method, query, ok := callQueryArg(call.Common(), tables)
+				if !ok {
+					continue
+				}
+
+				t := &taint{quoteFuncs: quoteFuncs, memo: newValueMemo()}
+				if dynamicQuery(query, newValueMemo()) && t.tainted(query) {
+					pass.Reportf(call.Pos(), "SQL query passed to %s is built from non-constant data, use query arguments instead", method.Name)
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// callQueryArg returns query argument of database method call
+func callQueryArg(common *ssa.CallCommon, tables []methodTable) (Method, ssa.Value, bool) {
+	fn, args := calleeFunc(common)
+	if fn == nil {
+		return Method{}, nil, false
+	}
+
+	for _, table := range tables {
+		method, ok := lookupFunc(fn, table)
+		if !ok || method.QueryArg == preparedQuery || method.QueryArg >= len(args) {
+			continue
+		}
+		return method, args[method.QueryArg], true
+	}
+
+	return Method{}, nil, false
+}
+
+// calleeFunc returns statically known function or interface method of call
+// and its arguments without receiver
+func calleeFunc(common *ssa.CallCommon) (*types.Func, []ssa.Value) {
+	if common.IsInvoke() {
+		return common.Method, common.Args
+	}
+
+	callee := common.StaticCallee()
+	if callee == nil {
+		return nil, nil
+	}
+
+	fn, ok := callee.Object().(*types.Func)
+	if !ok {
+		return nil, nil
+	}
+	if fn.Signature().Recv() != nil && len(common.Args) > 0 {
+		// skip receiver
+		return fn, common.Args[1:]
+	}
+	return fn, common.Args
+}
+
+// dynamicQuery reports whether query is built in place:
+// by concatenation, formatting or with strings.Builder
+func dynamicQuery(v ssa.Value, memo *valueMemo) bool {
+	return memo.check(v, func(v ssa.Value) bool {
+		switch v := v.(type) {
+		case *ssa.BinOp:
+			return v.Op == token.ADD
+		case *ssa.Phi:
+			return slices.ContainsFunc(v.Edges, func(edge ssa.Value) bool {
+				return dynamicQuery(edge, memo)
+			})
+		case *ssa.ChangeType:
+			return dynamicQuery(v.X, memo)
+		case *ssa.Call:
+			switch calleeName(v.Common()) {
+			case "fmt.Sprintf", "fmt.Sprint", "strings.Join", "(*strings.Builder).String":
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// valueMemo caches results of recursive checks of SSA values, so value used twice gets the same answer.
+// Values reference themselves only through phi nodes, so phi node reached again while being checked
+// is treated as negative, and negative results depending on it aren't cached.
+type valueMemo struct {
+	results    map[ssa.Value]bool
+	inProgress map[*ssa.Phi]bool
+	// incomplete reports whether current check reached phi node in progress
+	incomplete bool
+}
+
+func newValueMemo() *valueMemo {
+	return &valueMemo{results: make(map[ssa.Value]bool), inProgress: make(map[*ssa.Phi]bool)}
+}
+
+func (m *valueMemo) check(v ssa.Value, compute func(ssa.Value) bool) bool {
+	if result, ok := m.results[v]; ok {
+		return result
+	}
+
+	if phi, ok := v.(*ssa.Phi); ok {
+		if m.inProgress[phi] {
+			m.incomplete = true
+			return false
+		}
+		m.inProgress[phi] = true
+		defer delete(m.inProgress, phi)
+	}
+
+	outer := m.incomplete
+	m.incomplete = false
+	result := compute(v)
+	if result || !m.incomplete {
+		m.results[v] = result
+	}
+	m.incomplete = outer || m.incomplete
+	return result
+}
+
+// maxCallDepth limits depth of functions followed into while checking query
+const maxCallDepth = 3
+
+// taint checks whether string value is derived from non-constant data
+type taint struct {
+	quoteFuncs []string
+	memo       *valueMemo
+	// params holds taint of parameters of called function, parameters of analyzed function are tainted
+	params map[*ssa.Parameter]bool
+	depth  int
+}
+
+func (t *taint) tainted(v ssa.Value) bool {
+	return t.memo.check(v, t.taintedValue)
+}
+
+func (t *taint) taintedValue(v ssa.Value) bool {
+	if isSafeBasic(v.Type()) {
+		return false
+	}
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		return false
+
+	case *ssa.BinOp:
+		return t.tainted(v.X) || t.tainted(v.Y)
+
+	case *ssa.Phi:
+		return slices.ContainsFunc(v.Edges, t.tainted)
+
+	case *ssa.ChangeType:
+		return t.tainted(v.X)
+
+	case *ssa.Convert:
+		return t.tainted(v.X)
+
+	case *ssa.MakeInterface:
+		return t.tainted(v.X)
+
+	case *ssa.UnOp:
+		// package-level variables are considered to be static configuration
+		_, ok := v.X.(*ssa.Global)
+		return !(ok && v.Op == token.MUL)
+
+	case *ssa.Parameter:
+		if tainted, ok := t.params[v]; ok {
+			return tainted
+		}
+
+	case *ssa.Call:
+		return t.taintedCall(v.Common())
+
+	case *ssa.Extract:
+		return t.tainted(v.Tuple)
+	}
+
+	return true
+}
+
+func (t *taint) taintedCall(common *ssa.CallCommon) bool {
+	name := calleeName(common)
+	if slices.Contains(t.quoteFuncs, name) {
+		return false
+	}
+
+	switch name {
+	case "fmt.Sprintf":
+		if len(common.Args) != 2 {
+			return true
+		}
+		format, ok := common.Args[0].(*ssa.Const)
+		if !ok || format.Value == nil {
+			return true
+		}
+		return t.taintedFormat(constantString(format), common.Args[1])
+
+	case "fmt.Sprint":
+		args, ok := varargs(common.Args[0])
+		return !ok || slices.ContainsFunc(args, t.tainted)
+
+	case "strings.Join":
+		elems, ok := varargs(common.Args[0])
+		return !ok || slices.ContainsFunc(elems, t.tainted) || t.tainted(common.Args[1])
+
+	case "(*strings.Builder).String":
+		return t.taintedBuilder(common.Args[0])
+	}
+
+	if callee := common.StaticCallee(); callee != nil && len(callee.Blocks) > 0 && t.depth < maxCallDepth {
+		return t.taintedResults(callee, common.Args)
+	}
+
+	// results of other functions are derived from their receiver and arguments
+	if common.StaticCallee() == nil && t.tainted(common.Value) {
+		return true
+	}
+	return slices.ContainsFunc(common.Args, t.tainted)
+}
+
+// taintedResults checks values returned by function of analyzed package given its arguments
+func (t *taint) taintedResults(fn *ssa.Function, args []ssa.Value) bool {
+	callee := &taint{
+		quoteFuncs: t.quoteFuncs,
+		memo:       newValueMemo(),
+		params:     make(map[*ssa.Parameter]bool, len(fn.Params)),
+		depth:      t.depth + 1,
+	}
+	for i, param := range fn.Params {
+		callee.params[param] = i >= len(args) || t.tainted(args[i])
+	}
+
+	for _, block := range fn.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return)
+		if ok && slices.ContainsFunc(ret.Results, callee.tainted) {
+			return true
+		}
+	}
+	return false
+}
+
+// taintedFormat checks arguments of format string regardless of verbs,
+// numbers and booleans are safe with any of them
+func (t *taint) taintedFormat(format string, slice ssa.Value) bool {
+	verbs, ok := formatVerbs(format)
+	if !ok {
+		return true
+	}
+
+	args, ok := varargs(slice)
+	if !ok || len(args) != len(verbs) {
+		return true
+	}
+
+	return slices.ContainsFunc(args, t.tainted)
+}
+
+// taintedBuilder checks everything written to strings.Builder
+func (t *taint) taintedBuilder(ptr ssa.Value) bool {
+	alloc, ok := ptr.(*ssa.Alloc)
+	if !ok {
+		return true
+	}
+
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.Call:
+			switch calleeName(ref.Common()) {
+			case "(*strings.Builder).WriteString", "(*strings.Builder).WriteByte", "(*strings.Builder).WriteRune":
+				if t.tainted(ref.Common().Args[1]) {
+					return true
+				}
+			case "(*strings.Builder).String", "(*strings.Builder).Len", "(*strings.Builder).Reset", "(*strings.Builder).Grow":
+			default:
+				return true
+			}
+
+		case *ssa.MakeInterface:
+			// builder used as io.Writer
+			for _, use := range *ref.Referrers() {
+				call, ok := use.(*ssa.Call)
+				if !ok || calleeName(call.Common()) != "fmt.Fprintf" || call.Common().Args[0] != ref {
+					return true
+				}
+				format, ok := call.Common().Args[1].(*ssa.Const)
+				if !ok || format.Value == nil || t.taintedFormat(constantString(format), call.Common().Args[2]) {
+					return true
+				}
+			}
+
+		case *ssa.Store:
+			// zero value of composite literal
+			if _, ok := ref.Val.(*ssa.Const); !ok || ref.Addr != alloc {
+				return true
+			}
+
+		case *ssa.DebugRef:
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// varargs returns values of variadic arguments slice built in place, nil slice has no values
+func varargs(slice ssa.Value) ([]ssa.Value, bool) {
+	// call without variadic arguments passes nil slice
+	if c, ok := slice.(*ssa.Const); ok && c.IsNil() {
+		return nil, true
+	}
+
+	s, ok := slice.(*ssa.Slice)
+	if !ok {
+		return nil, false
+	}
+
+	alloc, ok := s.X.(*ssa.Alloc)
+	if !ok {
+		return nil, false
+	}
+
+	array, ok := alloc.Type().(*types.Pointer).Elem().Underlying().(*types.Array)
+	if !ok {
+		return nil, false
+	}
+
+	values := make([]ssa.Value, array.Len())
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		idx, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			return nil, false
+		}
+		for _, use := range *addr.Referrers() {
+			if store, ok := use.(*ssa.Store); ok && store.Addr == addr {
+				values[idx.Int64()] = store.Val
+			}
+		}
+	}
+
+	if slices.Contains(values, nil) {
+		return nil, false
+	}
+	return values, true
+}
+
+// formatVerbs returns verbs of format string in order of arguments
+func formatVerbs(format string) ([]rune, bool) {
+	var verbs []rune
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0123456789.*", format[i]) != -1 {
+			if format[i] == '*' {
+				return nil, false
+			}
+			i++
+		}
+		if i == len(format) {
+			return nil, false
+		}
+
+		switch format[i] {
+		case '%':
+		case '[':
+			// explicit argument indexes are not supported
+			return nil, false
+		default:
+			verbs = append(verbs, rune(format[i]))
+		}
+	}
+	return verbs, true
+}
+
+// calleeName returns full name of statically called function
+func calleeName(common *ssa.CallCommon) string {
+	if common.IsInvoke() {
+		return common.Method.FullName()
+	}
+
+	callee := common.StaticCallee()
+	if callee == nil {
+		return ""
+	}
+	if fn, ok := callee.Object().(*types.Func); ok {
+		return fn.FullName()
+	}
+	return ""
+}
+
+func constantString(c *ssa.Const) string {
+	if c.Value.Kind() != constant.String {
+		return ""
+	}
+	return constant.StringVal(c.Value)
+}
+
+// isSafeBasic reports whether values of type can't carry SQL syntax, e.g. numbers and booleans
+func isSafeBasic(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsNumeric|types.IsBoolean) != 0
+}
@@ -0,0 +1,261 @@
+package execinquery
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	execAlternative        = "Query or QueryRow"
+	execContextAlternative = "QueryContext or QueryRowContext"
+)
+
+// preparedQuery is a query argument index of methods,
+// which execute query previously prepared by receiver (e.g. sql.Stmt)
+const preparedQuery = -1
+
+// Method describes database method which executes or prepares query
+type Method struct {
+	// Recv is a receiver type name qualified by package path, e.g. database/sql.DB
+	Recv string
+	// Name is a method name
+	Name string
+	// QueryArg is an index of query argument
+	QueryArg int
+	// Alternative is a name of method to be used instead for queries of other kind.
+	// For query methods derived from Name if empty.
+	Alternative string
+}
+
+func (m Method) key() string {
+	return m.Recv + "." + m.Name
+}
+
+func (m Method) replacement() string {
+	if m.Alternative != "" {
+		return m.Alternative
+	}
+	if strings.Contains(m.Name, "Context") {
+		return "ExecContext"
+	}
+	return "Exec"
+}
+
+var (
+	sqlQueryMethods = []Method{
+		{Recv: "database/sql.DB", Name: "Query", QueryArg: 0},
+		{Recv: "database/sql.DB", Name: "QueryContext", QueryArg: 1},
+		{Recv: "database/sql.DB", Name: "QueryRow", QueryArg: 0},
+		{Recv: "database/sql.DB", Name: "QueryRowContext", QueryArg: 1},
+		{Recv: "database/sql.Tx", Name: "Query", QueryArg: 0},
+		{Recv: "database/sql.Tx", Name: "QueryContext", QueryArg: 1},
+		{Recv: "database/sql.Tx", Name: "QueryRow", QueryArg: 0},
+		{Recv: "database/sql.Tx", Name: "QueryRowContext", QueryArg: 1},
+		{Recv: "database/sql.Conn", Name: "QueryContext", QueryArg: 1},
+		{Recv: "database/sql.Conn", Name: "QueryRowContext", QueryArg: 1},
+		{Recv: "database/sql.Stmt", Name: "Query", QueryArg: preparedQuery},
+		{Recv: "database/sql.Stmt", Name: "QueryContext", QueryArg: preparedQuery},
+		{Recv: "database/sql.Stmt", Name: "QueryRow", QueryArg: preparedQuery},
+		{Recv: "database/sql.Stmt", Name: "QueryRowContext", QueryArg: preparedQuery},
+	}
+
+	sqlxQueryMethods = []Method{
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "Queryx", QueryArg: 0},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "QueryxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "QueryRowx", QueryArg: 0},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "QueryRowxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "Select", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "SelectContext", QueryArg: 2},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "Get", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "GetContext", QueryArg: 2},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "NamedQuery", QueryArg: 0, Alternative: "NamedExec"},
+		{Recv: "github.com/jmoiron/sqlx.DB", Name: "NamedQueryContext", QueryArg: 1, Alternative: "NamedExecContext"},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "Queryx", QueryArg: 0},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "QueryxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "QueryRowx", QueryArg: 0},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "QueryRowxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "Select", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "SelectContext", QueryArg: 2},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "Get", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "GetContext", QueryArg: 2},
+		{Recv: "github.com/jmoiron/sqlx.Tx", Name: "NamedQuery", QueryArg: 0, Alternative: "NamedExec"},
+		{Recv: "github.com/jmoiron/sqlx.Conn", Name: "QueryxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Conn", Name: "QueryRowxContext", QueryArg: 1},
+		{Recv: "github.com/jmoiron/sqlx.Conn", Name: "SelectContext", QueryArg: 2},
+		{Recv: "github.com/jmoiron/sqlx.Conn", Name: "GetContext", QueryArg: 2},
+	}
+
+	pgxQueryMethods = []Method{
+		{Recv: "github.com/jackc/pgx/v5.Conn", Name: "Query", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5.Conn", Name: "QueryRow", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5.Tx", Name: "Query", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5.Tx", Name: "QueryRow", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Pool", Name: "Query", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Pool", Name: "QueryRow", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Conn", Name: "Query", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Conn", Name: "QueryRow", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Tx", Name: "Query", QueryArg: 1},
+		{Recv: "github.com/jackc/pgx/v5/pgxpool.Tx", Name: "QueryRow", QueryArg: 1},
+	}
+
+	execMethods = newMethodTable(
+		Method{Recv: "database/sql.DB", Name: "Exec", QueryArg: 0, Alternative: execAlternative},
+		Method{Recv: "database/sql.DB", Name: "ExecContext", QueryArg: 1, Alternative: execContextAlternative},
+		Method{Recv: "database/sql.Tx", Name: "Exec", QueryArg: 0, Alternative: execAlternative},
+		Method{Recv: "database/sql.Tx", Name: "ExecContext", QueryArg: 1, Alternative: execContextAlternative},
+		Method{Recv: "database/sql.Conn", Name: "ExecContext", QueryArg: 1, Alternative: execContextAlternative},
+		Method{Recv: "database/sql.Stmt", Name: "Exec", QueryArg: preparedQuery, Alternative: execAlternative},
+		Method{Recv: "database/sql.Stmt", Name: "ExecContext", QueryArg: preparedQuery, Alternative: execContextAlternative},
+		Method{Recv: "github.com/jmoiron/sqlx.DB", Name: "MustExec", QueryArg: 0, Alternative: execAlternative},
+		Method{Recv: "github.com/jmoiron/sqlx.DB", Name: "MustExecContext", QueryArg: 1, Alternative: execContextAlternative},
+		Method{Recv: "github.com/jmoiron/sqlx.Tx", Name: "MustExec", QueryArg: 0, Alternative: execAlternative},
+		Method{Recv: "github.com/jmoiron/sqlx.Tx", Name: "MustExecContext", QueryArg: 1, Alternative: execContextAlternative},
+		Method{Recv: "github.com/jackc/pgx/v5.Conn", Name: "Exec", QueryArg: 1, Alternative: execAlternative},
+		Method{Recv: "github.com/jackc/pgx/v5.Tx", Name: "Exec", QueryArg: 1, Alternative: execAlternative},
+		Method{Recv: "github.com/jackc/pgx/v5/pgxpool.Pool", Name: "Exec", QueryArg: 1, Alternative: execAlternative},
+		Method{Recv: "github.com/jackc/pgx/v5/pgxpool.Conn", Name: "Exec", QueryArg: 1, Alternative: execAlternative},
+		Method{Recv: "github.com/jackc/pgx/v5/pgxpool.Tx", Name: "Exec", QueryArg: 1, Alternative: execAlternative},
+	)
+
+	prepareMethods = newMethodTable(
+		Method{Recv: "database/sql.DB", Name: "Prepare", QueryArg: 0},
+		Method{Recv: "database/sql.DB", Name: "PrepareContext", QueryArg: 1},
+		Method{Recv: "database/sql.Tx", Name: "Prepare", QueryArg: 0},
+		Method{Recv: "database/sql.Tx", Name: "PrepareContext", QueryArg: 1},
+		Method{Recv: "database/sql.Conn", Name: "PrepareContext", QueryArg: 1},
+		Method{Recv: "github.com/jmoiron/sqlx.DB", Name: "Preparex", QueryArg: 0},
+		Method{Recv: "github.com/jmoiron/sqlx.DB", Name: "PreparexContext", QueryArg: 1},
+		Method{Recv: "github.com/jmoiron/sqlx.Tx", Name: "Preparex", QueryArg: 0},
+		Method{Recv: "github.com/jmoiron/sqlx.Tx", Name: "PreparexContext", QueryArg: 1},
+		Method{Recv: "github.com/jmoiron/sqlx.Conn", Name: "PreparexContext", QueryArg: 1},
+	)
+)
+
+type methodTable map[string]Method
+
+type methodTables struct {
+	query methodTable
+	exec  methodTable
+}
+
+func newMethodTable(methods ...Method) methodTable {
+	table := make(methodTable, len(methods))
+	for _, m := range methods {
+		table[m.key()] = m
+	}
+	return table
+}
+
+// queryMethods returns built-in query methods extended with user defined ones
+func queryMethods(extra methodsFlag) methodTable {
+	methods := make([]Method, 0, len(sqlQueryMethods)+len(sqlxQueryMethods)+len(pgxQueryMethods)+len(extra))
+	methods = append(methods, sqlQueryMethods...)
+	methods = append(methods, sqlxQueryMethods...)
+	methods = append(methods, pgxQueryMethods...)
+	methods = append(methods, extra...)
+	return newMethodTable(methods...)
+}
+
+// lookupMethod returns table entry of method called in given expression.
+// Methods promoted from embedded fields are resolved to their origin receiver.
+func lookupMethod(pass *analysis.Pass, call *ast.CallExpr, table methodTable) (Method, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return Method{}, false
+	}
+
+	return lookupFunc(fn, table)
+}
+
+// lookupFunc returns table entry of given method
+func lookupFunc(fn *types.Func, table methodTable) (Method, bool) {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return Method{}, false
+	}
+
+	recvName, ok := qualifiedTypeName(recv.Type())
+	if !ok {
+		return Method{}, false
+	}
+
+	m, ok := table[Method{Recv: recvName, Name: fn.Name()}.key()]
+	return m, ok
+}
+
+func qualifiedTypeName(typ types.Type) (string, bool) {
+	typ = types.Unalias(typ)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = types.Unalias(ptr.Elem())
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", false
+	}
+
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name(), true
+}
+
+// methodsFlag is a flag.Value of user defined query methods.
+// Repeated flags extend the list, empty value resets it.
+type methodsFlag []Method
+
+func (f *methodsFlag) Set(v string) error {
+	if strings.TrimSpace(v) == "" {
+		*f = nil
+		return nil
+	}
+
+	for _, entry := range strings.Split(v, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		m, err := parseMethod(entry)
+		if err != nil {
+			return err
+		}
+		*f = append(*f, m)
+	}
+	return nil
+}
+
+func (f *methodsFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	entries := make([]string, 0, len(*f))
+	for _, m := range *f {
+		entries = append(entries, m.key()+":"+strconv.Itoa(m.QueryArg))
+	}
+	return strings.Join(entries, ",")
+}
+
+// parseMethod parses method in form pkg/path.Type.Method:N
+func parseMethod(entry string) (Method, error) {
+	name, idx, ok := strings.Cut(entry, ":")
+	if !ok {
+		return Method{}, fmt.Errorf("invalid query method %q: missing query argument index", entry)
+	}
+
+	queryArg, err := strconv.Atoi(idx)
+	if err != nil || queryArg < 0 {
+		return Method{}, fmt.Errorf("invalid query method %q: bad query argument index %q", entry, idx)
+	}
+
+	dot := strings.LastIndex(name, ".")
+	if dot <= 0 || dot == len(name)-1 || strings.LastIndex(name[:dot], ".") <= strings.LastIndex(name[:dot], "/") {
+		return Method{}, fmt.Errorf("invalid query method %q: expected pkg/path.Type.Method", entry)
+	}
+
+	return Method{Recv: name[:dot], Name: name[dot+1:], QueryArg: queryArg}, nil
+}
@@ -0,0 +1,175 @@
+package execinquery
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+const (
+	sqlNamedArg = "database/sql.NamedArg"
+	pgxPath     = "github.com/jackc/pgx/v5"
+)
+
+func init() {
+	PlaceholdersAnalyzer.Flags.Var(&placeholdersMethods, "methods",
+		"comma-separated list of additional query methods in form pkg/path.Type.Method:N, where N is index of query argument")
+	PlaceholdersAnalyzer.Flags.Var(&placeholdersDialect, "dialect",
+		"SQL dialect of queries: postgres, mysql, clickhouse or sqlite")
+}
+
+var (
+	placeholdersMethods methodsFlag
+	placeholdersDialect dialectFlag
+)
+
+// PlaceholdersAnalyzer checks that number of query placeholders matches number of arguments
+var PlaceholdersAnalyzer = &analysis.Analyzer{
+	Name: "sqlplaceholders",
+	Doc:  `sqlplaceholders checks that number of placeholders in SQL query matches number of passed arguments`,
+	Run:  sqlplaceholders,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		StringsAnalyzer,
+	},
+}
+
+func sqlplaceholders(pass *analysis.Pass) (any, error) {
+	queries := queryMethods(placeholdersMethods)
+	dialect := placeholdersDialect.get()
+
+	inspectQueryCalls(pass, func(callExpr *ast.CallExpr, r *resolver) {
+		method, ok := lookupMethod(pass, callExpr, queries)
+		if !ok {
+			method, ok = lookupMethod(pass, callExpr, execMethods)
+		}
+		if !ok {
+			return
+		}
+
+		checkPlaceholders(pass, callExpr, method, dialect, r)
+	})
+
+	return nil, nil
+}
+
+// placeholders holds placeholders used in query
+type placeholders struct {
+	// positional is a number of ? placeholders
+	positional int
+	// numbered holds numbers of $N placeholders
+	numbered map[int]bool
+	// named holds names of :name and @name placeholders
+	named map[string]bool
+}
+
+func parsePlaceholders(query string, d *dialect) placeholders {
+	p := placeholders{numbered: make(map[int]bool), named: make(map[string]bool)}
+	for _, t := range tokenize(query, d) {
+		if t.kind != tokenParam {
+			continue
+		}
+
+		switch t.text[0] {
+		case '?':
+			p.positional++
+		case '$':
+			if n, err := strconv.Atoi(t.text[1:]); err == nil {
+				p.numbered[n] = true
+			}
+		default:
+			p.named[t.text[1:]] = true
+		}
+	}
+	return p
+}
+
+// styles returns number of placeholder styles used in query
+func (p placeholders) styles() int {
+	styles := 0
+	for _, used := range []bool{p.positional > 0, len(p.numbered) > 0, len(p.named) > 0} {
+		if used {
+			styles++
+		}
+	}
+	return styles
+}
+
+// maxNumbered returns the largest number of $N placeholders
+func (p placeholders) maxNumbered() int {
+	maxN := 0
+	for n := range p.numbered {
+		maxN = max(maxN, n)
+	}
+	return maxN
+}
+
+func checkPlaceholders(pass *analysis.Pass, callExpr *ast.CallExpr, method Method, d *dialect, r *resolver) {
+	// arguments spread from slice can't be counted
+	if callExpr.Ellipsis.IsValid() {
+		return
+	}
+
+	sig, ok := pass.TypesInfo.TypeOf(callExpr.Fun).(*types.Signature)
+	if !ok || !sig.Variadic() {
+		return
+	}
+	args := callExpr.Args[sig.Params().Len()-1:]
+
+	query, ok := queryArgString(callExpr, method, r)
+	if !ok {
+		return
+	}
+
+	p := parsePlaceholders(query, d)
+	if p.styles() > 1 {
+		return
+	}
+
+	namedArgs := 0
+	for _, arg := range args {
+		name, ok := qualifiedTypeName(pass.TypesInfo.TypeOf(arg))
+		if !ok {
+			continue
+		}
+		// pgx query options and named arguments (e.g. pgx.QueryExecMode, pgx.NamedArgs) are not query parameters
+		if strings.HasPrefix(name, pgxPath+".") {
+			return
+		}
+		if name == sqlNamedArg {
+			namedArgs++
+		}
+	}
+
+	var expected int
+	switch {
+	case p.positional > 0:
+		expected = p.positional
+	case len(p.numbered) > 0:
+		expected = p.maxNumbered()
+		for n := 1; n < expected; n++ {
+			if !p.numbered[n] {
+				pass.Reportf(callExpr.Fun.Pos(), "Placeholder $%d is missing in query passed to %s", n, method.Name)
+			}
+		}
+	case len(p.named) > 0:
+		// named placeholders can be checked only against sql.Named arguments
+		if namedArgs != len(args) {
+			return
+		}
+		expected = len(p.named)
+	}
+
+	switch {
+	case len(args) < expected:
+		pass.Reportf(callExpr.Fun.Pos(), "Not enough arguments in call to %s: query has %d placeholders, but %d arguments given",
+			method.Name, expected, len(args))
+	case len(args) > expected:
+		pass.Reportf(callExpr.Fun.Pos(), "Too many arguments in call to %s: query has %d placeholders, but %d arguments given",
+			method.Name, expected, len(args))
+	}
+}
@@ -0,0 +1,587 @@
+package execinquery
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// resolver evaluates string values of query expressions on a best-effort basis.
+//
+// Local variables are tracked in source order. Once variable is assigned in a nested
+// block (e.g. conditionally or in a loop) its value becomes unknown. Variables, which
+// may be changed indirectly (e.g. through a pointer), are never resolved.
+type resolver struct {
+	pass *analysis.Pass
+	// values holds known values of variables and strings.Builder contents
+	values map[types.Object]string
+	// lists holds values of package-level string slices and arrays, which are never modified
+	lists map[types.Object][]string
+	// escaped holds variables, which may be changed indirectly
+	escaped map[types.Object]bool
+	// imported holds values of exported package-level strings of imported packages
+	imported map[*types.Package]map[string]string
+}
+
+func newResolver(pass *analysis.Pass, globals *resolver) *resolver {
+	return &resolver{
+		pass:     pass,
+		values:   maps.Clone(globals.values),
+		lists:    globals.lists,
+		escaped:  globals.escaped,
+		imported: globals.imported,
+	}
+}
+
+// collectGlobals returns resolver with values of package-level string constants,
+// string variables and string slices, which are never modified
+func collectGlobals(pass *analysis.Pass) *resolver {
+	reassigned := reassignedVars(pass)
+	r := &resolver{
+		pass:     pass,
+		values:   make(map[types.Object]string),
+		lists:    make(map[types.Object][]string),
+		escaped:  escapedVars(pass),
+		imported: importedStrings(pass),
+	}
+
+	for _, name := range pass.Pkg.Scope().Names() {
+		if c, ok := pass.Pkg.Scope().Lookup(name).(*types.Const); ok && c.Val().Kind() == constant.String {
+			r.values[c] = constant.StringVal(c.Val())
+		}
+	}
+
+	// initializers are ordered by dependencies, so referenced variables are already evaluated
+	for _, init := range pass.TypesInfo.InitOrder {
+		if len(init.Lhs) != 1 || reassigned[init.Lhs[0]] || r.escaped[init.Lhs[0]] {
+			continue
+		}
+		if isStringList(init.Lhs[0].Type()) {
+			if elems, ok := r.resolveStrings(init.Rhs); ok {
+				r.lists[init.Lhs[0]] = elems
+			}
+			continue
+		}
+		if v, ok := r.resolve(init.Rhs); ok {
+			r.values[init.Lhs[0]] = v
+		}
+	}
+
+	return r
+}
+
+// reassignedVars returns package-level variables, which are assigned outside of their declaration
+func reassignedVars(pass *analysis.Pass) map[types.Object]bool {
+	reassigned := make(map[types.Object]bool)
+	mark := func(expr ast.Expr) {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return
+		}
+		if v, ok := pass.TypesInfo.Uses[ident].(*types.Var); ok && v.Parent() == pass.Pkg.Scope() {
+			reassigned[v] = true
+		}
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					mark(lhs)
+				}
+			case *ast.IncDecStmt:
+				mark(n.X)
+			}
+			return true
+		})
+	}
+
+	return reassigned
+}
+
+// escapedVars returns variables, which may be changed behind the back of resolver:
+// variables whose address is taken (e.g. fmt.Sscan(s, &q)), pointers to strings.Builder
+// passed around and package-level string slices used other than read-only.
+// Taking address of strings.Builder for tracked fmt.Fprint and fmt.Fprintf calls is allowed.
+func escapedVars(pass *analysis.Pass) map[types.Object]bool {
+	escaped := make(map[types.Object]bool)
+
+	for _, file := range pass.Files {
+		var stack []ast.Node
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+
+			if ident, ok := n.(*ast.Ident); ok {
+				v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+				if ok && !v.IsField() && !safeUse(pass, v, ident, stack) {
+					escaped[v] = true
+				}
+			}
+
+			stack = append(stack, n)
+			return true
+		})
+	}
+
+	return escaped
+}
+
+// safeUse reports whether use of variable can't change its value unnoticed by resolver
+func safeUse(pass *analysis.Pass, v *types.Var, ident *ast.Ident, stack []ast.Node) bool {
+	parent := func(i int) ast.Node {
+		if i < len(stack) {
+			return stack[len(stack)-1-i]
+		}
+		return nil
+	}
+
+	if unary, ok := parent(0).(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return isWriteArg(pass, parent(1), unary)
+	}
+
+	switch {
+	case isBuilderPointer(v.Type()):
+		// pointer to strings.Builder may only be used as receiver, tracked writer or reassigned
+		switch p := parent(0).(type) {
+		case *ast.SelectorExpr:
+			return p.X == ident
+		case *ast.AssignStmt:
+			return slices.Contains(p.Lhs, ast.Expr(ident))
+		default:
+			return isWriteArg(pass, p, ident)
+		}
+
+	case isStringList(v.Type()) && v.Parent() == pass.Pkg.Scope():
+		// package-level string slice may only be read
+		switch p := parent(0).(type) {
+		case *ast.CallExpr:
+			fn := typeutil.Callee(pass.TypesInfo, p)
+			if b, ok := fn.(*types.Builtin); ok {
+				return b.Name() == "len" || b.Name() == "cap"
+			}
+			f, ok := fn.(*types.Func)
+			return ok && f.FullName() == "strings.Join" && len(p.Args) > 0 && p.Args[0] == ident
+		case *ast.IndexExpr:
+			return p.X == ident && !isModified(parent(1), p)
+		case *ast.RangeStmt:
+			return p.X == ident
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// isWriteArg reports whether expression is a writer of fmt.Fprint or fmt.Fprintf call tracked by resolver
+func isWriteArg(pass *analysis.Pass, n ast.Node, arg ast.Expr) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 || call.Args[0] != arg {
+		return false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && (fn.FullName() == "fmt.Fprint" || fn.FullName() == "fmt.Fprintf")
+}
+
+// isModified reports whether expression is assigned or its address is taken in parent node
+func isModified(parent ast.Node, expr ast.Expr) bool {
+	switch p := parent.(type) {
+	case *ast.AssignStmt:
+		return slices.Contains(p.Lhs, expr)
+	case *ast.IncDecStmt:
+		return p.X == expr
+	case *ast.UnaryExpr:
+		return p.Op == token.AND
+	case *ast.RangeStmt:
+		return p.Key == expr || p.Value == expr
+	}
+	return false
+}
+
+// visit tracks values of variables changed by given node
+func (r *resolver) visit(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		r.assign(n)
+	case *ast.DeclStmt:
+		r.declare(n)
+	case *ast.CallExpr:
+		r.write(n)
+	}
+}
+
+func (r *resolver) assign(stmt *ast.AssignStmt) {
+	for i, lhs := range stmt.Lhs {
+		ident, ok := ast.Unparen(lhs).(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+
+		obj := r.pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			continue
+		}
+
+		if i >= len(stmt.Rhs) || r.conditional(obj, stmt.Pos()) {
+			delete(r.values, obj)
+			continue
+		}
+
+		v, ok := r.resolve(stmt.Rhs[i])
+		if stmt.Tok == token.ADD_ASSIGN {
+			prev, known := r.values[obj]
+			v, ok = prev+v, ok && known
+		}
+
+		if !ok {
+			delete(r.values, obj)
+			continue
+		}
+		r.values[obj] = v
+	}
+}
+
+func (r *resolver) declare(stmt *ast.DeclStmt) {
+	decl, ok := stmt.Decl.(*ast.GenDecl)
+	if !ok || decl.Tok != token.VAR {
+		return
+	}
+
+	for _, spec := range decl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		for i, ident := range valueSpec.Names {
+			obj := r.pass.TypesInfo.Defs[ident]
+			if obj == nil {
+				continue
+			}
+
+			if len(valueSpec.Values) == 0 {
+				// zero value of string or strings.Builder
+				if isString(obj.Type()) || isBuilder(obj.Type()) {
+					r.values[obj] = ""
+				}
+				continue
+			}
+
+			if i < len(valueSpec.Values) {
+				if v, ok := r.resolve(valueSpec.Values[i]); ok {
+					r.values[obj] = v
+				}
+			}
+		}
+	}
+}
+
+// write tracks contents of strings.Builder
+func (r *resolver) write(call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(r.pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return
+	}
+
+	var (
+		target ast.Expr
+		text   string
+		known  bool
+	)
+
+	switch fn.FullName() {
+	case "(*strings.Builder).WriteString":
+		target = recvExpr(call)
+		if len(call.Args) == 1 {
+			text, known = r.resolve(call.Args[0])
+		}
+	case "(*strings.Builder).WriteByte", "(*strings.Builder).WriteRune":
+		target = recvExpr(call)
+		if len(call.Args) == 1 {
+			if tv := r.pass.TypesInfo.Types[call.Args[0]]; tv.Value != nil {
+				if c, ok := constant.Int64Val(tv.Value); ok {
+					text, known = string(rune(c)), true
+				}
+			}
+		}
+	case "(*strings.Builder).Reset":
+		target = recvExpr(call)
+		known = true
+	case "(*strings.Builder).Write":
+		// contents written from byte slice are unknown
+		target = recvExpr(call)
+	case "fmt.Fprintf", "fmt.Fprint":
+		if len(call.Args) == 0 || !isBuilder(r.pass.TypesInfo.TypeOf(call.Args[0])) {
+			return
+		}
+		target = call.Args[0]
+		text, known = r.sprint(fn.Name(), call.Args[1:])
+	default:
+		return
+	}
+
+	obj := r.builderObject(target)
+	if obj == nil {
+		return
+	}
+
+	prev, ok := r.values[obj]
+	if fn.Name() == "Reset" {
+		prev, ok = "", true
+	}
+	if !ok || !known || r.conditional(obj, call.Pos()) {
+		delete(r.values, obj)
+		return
+	}
+	r.values[obj] = prev + text
+}
+
+func (r *resolver) builderObject(expr ast.Expr) types.Object {
+	if expr == nil {
+		return nil
+	}
+
+	expr = ast.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = ast.Unparen(unary.X)
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	return r.pass.TypesInfo.Uses[ident]
+}
+
+// conditional reports whether variable is changed at given position
+// in a scope nested into the scope of its declaration
+func (r *resolver) conditional(obj types.Object, pos token.Pos) bool {
+	return r.pass.Pkg.Scope().Innermost(pos) != obj.Parent()
+}
+
+// resolve returns string value of expression if it can be evaluated statically
+func (r *resolver) resolve(expr ast.Expr) (string, bool) {
+	expr = ast.Unparen(expr)
+
+	if tv, ok := r.pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		if tv.Value.Kind() != constant.String {
+			return "", false
+		}
+		return constant.StringVal(tv.Value), true
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return r.lookup(r.pass.TypesInfo.Uses[e])
+
+	case *ast.SelectorExpr:
+		return r.lookup(r.pass.TypesInfo.Uses[e.Sel])
+
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		x, okX := r.resolve(e.X)
+		y, okY := r.resolve(e.Y)
+		return x + y, okX && okY
+
+	case *ast.CallExpr:
+		return r.resolveCall(e)
+
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			// address of strings.Builder
+			return r.resolve(e.X)
+		}
+
+	case *ast.CompositeLit:
+		if isBuilder(r.pass.TypesInfo.TypeOf(e)) && len(e.Elts) == 0 {
+			return "", true
+		}
+	}
+
+	return "", false
+}
+
+func (r *resolver) lookup(obj types.Object) (string, bool) {
+	if obj == nil || r.escaped[obj] {
+		return "", false
+	}
+
+	if v, ok := r.values[obj]; ok {
+		return v, true
+	}
+
+	// package-level string of imported package
+	if obj.Pkg() == nil || obj.Pkg() == r.pass.Pkg || obj.Parent() != obj.Pkg().Scope() {
+		return "", false
+	}
+
+	v, ok := r.imported[obj.Pkg()][obj.Name()]
+	return v, ok
+}
+
+func (r *resolver) resolveCall(call *ast.CallExpr) (string, bool) {
+	// statement preparation keeps query for later execution
+	if method, ok := lookupMethod(r.pass, call, prepareMethods); ok {
+		if method.QueryArg >= len(call.Args) {
+			return "", false
+		}
+		return r.resolve(call.Args[method.QueryArg])
+	}
+
+	switch fn := typeutil.Callee(r.pass.TypesInfo, call).(type) {
+	case *types.Builtin:
+		if fn.Name() == "new" && isBuilder(r.pass.TypesInfo.TypeOf(call)) {
+			return "", true
+		}
+	case *types.Func:
+		return r.resolveFuncCall(call, fn)
+	}
+
+	return "", false
+}
+
+func (r *resolver) resolveFuncCall(call *ast.CallExpr, fn *types.Func) (string, bool) {
+	switch fn.FullName() {
+	case "fmt.Sprintf", "fmt.Sprint":
+		return r.sprint(fn.Name(), call.Args)
+
+	case "strings.Join":
+		if len(call.Args) != 2 {
+			return "", false
+		}
+		elems, ok := r.resolveStrings(call.Args[0])
+		if !ok {
+			return "", false
+		}
+		sep, ok := r.resolve(call.Args[1])
+		return strings.Join(elems, sep), ok
+
+	case "(*strings.Builder).String":
+		if recv := recvExpr(call); recv != nil {
+			return r.resolve(recv)
+		}
+	}
+
+	return "", false
+}
+
+// sprint evaluates fmt.Sprint* family call with constant arguments
+func (r *resolver) sprint(name string, args []ast.Expr) (string, bool) {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		tv, ok := r.pass.TypesInfo.Types[arg]
+		if ok && tv.Value != nil {
+			switch tv.Value.Kind() {
+			case constant.Bool:
+				values = append(values, constant.BoolVal(tv.Value))
+			case constant.Int:
+				v, exact := constant.Int64Val(tv.Value)
+				if !exact {
+					return "", false
+				}
+				values = append(values, v)
+			case constant.Float:
+				v, _ := constant.Float64Val(tv.Value)
+				values = append(values, v)
+			case constant.String:
+				values = append(values, constant.StringVal(tv.Value))
+			default:
+				return "", false
+			}
+			continue
+		}
+
+		v, ok := r.resolve(arg)
+		if !ok {
+			return "", false
+		}
+		values = append(values, v)
+	}
+
+	if strings.HasSuffix(name, "f") {
+		if len(values) == 0 {
+			return "", false
+		}
+		format, ok := values[0].(string)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf(format, values[1:]...), true
+	}
+
+	return fmt.Sprint(values...), true
+}
+
+// resolveStrings returns values of string slice literal or package-level string slice
+func (r *resolver) resolveStrings(expr ast.Expr) ([]string, bool) {
+	var lit *ast.CompositeLit
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		lit = e
+	case *ast.Ident:
+		elems, ok := r.lists[r.pass.TypesInfo.Uses[e]]
+		return elems, ok
+	default:
+		return nil, false
+	}
+
+	elems := make([]string, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		v, ok := r.resolve(elt)
+		if !ok {
+			return nil, false
+		}
+		elems = append(elems, v)
+	}
+	return elems, true
+}
+
+// recvExpr returns receiver of method call
+func recvExpr(call *ast.CallExpr) ast.Expr {
+	if selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		return selector.X
+	}
+	return nil
+}
+
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isStringList reports whether typ is a slice or an array of strings
+func isStringList(typ types.Type) bool {
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return isString(t.Elem())
+	case *types.Array:
+		return isString(t.Elem())
+	}
+	return false
+}
+
+func isBuilderPointer(typ types.Type) bool {
+	ptr, ok := typ.(*types.Pointer)
+	return ok && isBuilder(ptr.Elem())
+}
+
+func isBuilder(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "strings" && named.Obj().Name() == "Builder"
+}
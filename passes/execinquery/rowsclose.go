@@ -0,0 +1,419 @@
+package execinquery
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// rowsTypes are types of query results, which must be closed
+var rowsTypes = []string{
+	"database/sql.Rows",
+	"github.com/jmoiron/sqlx.Rows",
+	pgxPath + ".Rows",
+}
+
+func init() {
+	RowsCloseAnalyzer.Flags.Var(&rowsCloseMethods, "methods",
+		"comma-separated list of additional query methods in form pkg/path.Type.Method:N, where N is index of query argument")
+}
+
+var rowsCloseMethods methodsFlag
+
+// RowsCloseAnalyzer checks that rows returned by query are closed and checked for iteration error
+var RowsCloseAnalyzer = &analysis.Analyzer{
+	Name: "rowsclose",
+	Doc:  `rowsclose checks that rows returned by Query are closed on all paths and rows.Err() is checked after iteration`,
+	Run:  rowsclose,
+	Requires: []*analysis.Analyzer{
+		buildssa.Analyzer,
+	},
+}
+
+func rowsclose(pass *analysis.Pass) (any, error) {
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	queries := queryMethods(rowsCloseMethods)
+
+	for _, fn := range ssaInput.SrcFuncs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+
+				callee, _ := calleeFunc(call.Common())
+				if callee == nil {
+					continue
+				}
+				if _, ok := lookupFunc(callee, queries); !ok {
+					continue
+				}
+
+				rows, err := queryResults(call)
+				if rows == nil {
+					continue
+				}
+
+				checkRows(pass, call, callee.Name(), rows, err)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// queryResults returns rows and error values of query call
+func queryResults(call *ssa.Call) (rows, err ssa.Value) {
+	if isRowsType(call.Type()) {
+		return call, nil
+	}
+
+	for _, ref := range *call.Referrers() {
+		extract, ok := ref.(*ssa.Extract)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case isRowsType(extract.Type()):
+			rows = extract
+		case types.Identical(extract.Type(), types.Universe.Lookup("error").Type()):
+			err = extract
+		}
+	}
+	return rows, err
+}
+
+func isRowsType(typ types.Type) bool {
+	name, ok := qualifiedTypeName(typ)
+	return ok && slices.Contains(rowsTypes, name)
+}
+
+// rowsUsage holds instructions using rows value
+type rowsUsage struct {
+	// closed holds instructions, after which rows are closed or owned by someone else:
+	// Close calls, deferred Close calls, returns, stores to fields and passes to other functions
+	closed map[ssa.Instruction]bool
+	// escaped reports whether rows are returned, stored or passed to other functions
+	escaped bool
+	// next holds rows.Next() calls
+	next []*ssa.Call
+	// errChecks holds rows.Err() calls
+	errChecks []ssa.Instruction
+	// errVars holds values, which hold query error
+	errVars map[ssa.Value]bool
+}
+
+func checkRows(pass *analysis.Pass, call *ssa.Call, method string, rows, err ssa.Value) {
+	u := &rowsUsage{closed: make(map[ssa.Instruction]bool), errVars: make(map[ssa.Value]bool)}
+	u.collect(rows, make(map[ssa.Value]bool))
+	if err != nil {
+		u.collectErr(err)
+	}
+
+	if !u.closedOnAllPaths(call) {
+		pass.Reportf(call.Pos(), "Rows returned by %s must be closed on all paths: call Close, defer it or return rows", method)
+	}
+
+	if u.escaped {
+		return
+	}
+
+	for _, next := range u.next {
+		if !u.errCheckedAfter(next) {
+			pass.Reportf(next.Pos(), "Rows iteration with Next must be followed by Err check")
+			// single report per rows is enough
+			return
+		}
+	}
+}
+
+// collect walks through uses of rows value and its aliases
+func (u *rowsUsage) collect(v ssa.Value, visited map[ssa.Value]bool) {
+	if visited[v] {
+		return
+	}
+	visited[v] = true
+
+	refs := v.Referrers()
+	if refs == nil {
+		return
+	}
+
+	for _, ref := range *refs {
+		switch ref := ref.(type) {
+		case ssa.CallInstruction:
+			u.collectCall(v, ref)
+
+		case *ssa.Phi:
+			u.collect(ref, visited)
+		case *ssa.ChangeType:
+			u.collect(ref, visited)
+		case *ssa.MakeInterface:
+			u.collect(ref, visited)
+		case *ssa.ChangeInterface:
+			u.collect(ref, visited)
+		case *ssa.TypeAssert:
+			u.collect(ref, visited)
+
+		case *ssa.FieldAddr:
+			// promoted methods of embedded rows, e.g. (*sqlx.Rows).Close
+			for _, use := range *ref.Referrers() {
+				if load, ok := use.(*ssa.UnOp); ok && load.Op == token.MUL {
+					u.collect(load, visited)
+				}
+			}
+		case *ssa.Field:
+			u.collect(ref, visited)
+
+		case *ssa.Store:
+			if ref.Val != v {
+				continue
+			}
+			if alloc, ok := ref.Addr.(*ssa.Alloc); ok {
+				// local variable captured by closure or taken by address
+				u.collectVar(alloc, visited)
+				continue
+			}
+			u.escape(ref)
+
+		case *ssa.MakeClosure:
+			u.collectClosure(v, ref)
+		case *ssa.Return, *ssa.Send, *ssa.MapUpdate:
+			u.escape(ref)
+		}
+	}
+}
+
+// collectVar walks through loads of local variable holding rows
+func (u *rowsUsage) collectVar(alloc *ssa.Alloc, visited map[ssa.Value]bool) {
+	if visited[alloc] {
+		return
+	}
+	visited[alloc] = true
+
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.UnOp:
+			if ref.Op == token.MUL {
+				u.collect(ref, visited)
+			}
+		case *ssa.Store:
+		case *ssa.MakeClosure:
+			u.collectClosure(alloc, ref)
+		default:
+			// passed by pointer
+			u.escape(ref)
+		}
+	}
+}
+
+// collectClosure checks closure capturing rows. Closure, which only closes rows,
+// closes them where it's called or deferred, otherwise rows escape to it.
+func (u *rowsUsage) collectClosure(v ssa.Value, closure *ssa.MakeClosure) {
+	fn := closure.Fn.(*ssa.Function)
+	idx := slices.Index(closure.Bindings, v)
+	_, captured := v.(*ssa.Alloc)
+	if idx < 0 || idx >= len(fn.FreeVars) || !closesOnly(fn.FreeVars[idx], captured) {
+		u.escape(closure)
+		return
+	}
+
+	for _, ref := range *closure.Referrers() {
+		call, ok := ref.(ssa.CallInstruction)
+		if !ok || call.Common().Value != closure {
+			u.escape(closure)
+			return
+		}
+		u.closed[call] = true
+	}
+}
+
+// closesOnly reports whether captured rows or variable holding them are only closed in closure
+func closesOnly(fv *ssa.FreeVar, captured bool) bool {
+	uses := []ssa.Value{fv}
+	if captured {
+		// captured variable is loaded before use
+		uses = uses[:0]
+		for _, ref := range *fv.Referrers() {
+			load, ok := ref.(*ssa.UnOp)
+			if !ok || load.Op != token.MUL {
+				return false
+			}
+			uses = append(uses, load)
+		}
+	}
+
+	closed := false
+	for _, v := range uses {
+		for _, ref := range *v.Referrers() {
+			call, ok := ref.(ssa.CallInstruction)
+			if !ok || !isCloseCall(v, call) {
+				return false
+			}
+			closed = true
+		}
+	}
+	return closed
+}
+
+// isCloseCall reports whether call is rows.Close()
+func isCloseCall(rows ssa.Value, call ssa.CallInstruction) bool {
+	common := call.Common()
+	if common.IsInvoke() {
+		return common.Value == rows && common.Method.Name() == "Close"
+	}
+	fn, _ := calleeFunc(common)
+	return fn != nil && fn.Name() == "Close" && fn.Signature().Recv() != nil &&
+		len(common.Args) > 0 && common.Args[0] == rows
+}
+
+func (u *rowsUsage) collectCall(v ssa.Value, call ssa.CallInstruction) {
+	common := call.Common()
+
+	var (
+		recv ssa.Value
+		name string
+	)
+	if common.IsInvoke() {
+		recv, name = common.Value, common.Method.Name()
+	} else if fn, _ := calleeFunc(common); fn != nil && fn.Signature().Recv() != nil && len(common.Args) > 0 {
+		recv, name = common.Args[0], fn.Name()
+	}
+
+	if recv != v {
+		// rows passed to other function
+		u.escape(call)
+		return
+	}
+
+	switch name {
+	case "Close":
+		u.closed[call] = true
+	case "Next":
+		if c, ok := call.(*ssa.Call); ok {
+			u.next = append(u.next, c)
+		}
+	case "Err":
+		u.errChecks = append(u.errChecks, call)
+	}
+}
+
+func (u *rowsUsage) escape(instr ssa.Instruction) {
+	u.closed[instr] = true
+	u.escaped = true
+}
+
+// collectErr collects values holding query error
+func (u *rowsUsage) collectErr(err ssa.Value) {
+	u.errVars[err] = true
+	for _, ref := range *err.Referrers() {
+		store, ok := ref.(*ssa.Store)
+		if !ok || store.Val != err {
+			continue
+		}
+		alloc, ok := store.Addr.(*ssa.Alloc)
+		if !ok {
+			continue
+		}
+		for _, use := range *alloc.Referrers() {
+			if load, ok := use.(*ssa.UnOp); ok && load.Op == token.MUL {
+				u.errVars[load] = true
+			}
+		}
+	}
+}
+
+// closedOnAllPaths reports whether every path from query call to function exit passes
+// through closing instruction. Paths, where query returned an error, are skipped.
+func (u *rowsUsage) closedOnAllPaths(call *ssa.Call) bool {
+	visited := make(map[*ssa.BasicBlock]bool)
+
+	var walk func(block *ssa.BasicBlock, from int) bool
+	walk = func(block *ssa.BasicBlock, from int) bool {
+		for _, instr := range block.Instrs[from:] {
+			if u.closed[instr] {
+				return true
+			}
+		}
+
+		switch last := block.Instrs[len(block.Instrs)-1].(type) {
+		case *ssa.Return:
+			return false
+		case *ssa.Panic:
+			return true
+		case *ssa.If:
+			if succ, ok := u.successOnly(last); ok {
+				return walkBlock(block.Succs[succ], walk, visited)
+			}
+		}
+
+		for _, succ := range block.Succs {
+			if !walkBlock(succ, walk, visited) {
+				return false
+			}
+		}
+		return true
+	}
+
+	visited[call.Block()] = true
+	return walk(call.Block(), slices.Index(call.Block().Instrs, ssa.Instruction(call))+1)
+}
+
+func walkBlock(block *ssa.BasicBlock, walk func(*ssa.BasicBlock, int) bool, visited map[*ssa.BasicBlock]bool) bool {
+	if visited[block] {
+		return true
+	}
+	visited[block] = true
+	return walk(block, 0)
+}
+
+// successOnly returns index of successor of error check, where query succeeded
+func (u *rowsUsage) successOnly(branch *ssa.If) (int, bool) {
+	cond, ok := branch.Cond.(*ssa.BinOp)
+	if !ok || cond.Op != token.NEQ && cond.Op != token.EQL {
+		return 0, false
+	}
+
+	isNil := func(v ssa.Value) bool {
+		c, ok := v.(*ssa.Const)
+		return ok && c.IsNil()
+	}
+	if !(u.errVars[cond.X] && isNil(cond.Y) || u.errVars[cond.Y] && isNil(cond.X)) {
+		return 0, false
+	}
+
+	// if err != nil { ... } else { ... }
+	if cond.Op == token.NEQ {
+		return 1, true
+	}
+	return 0, true
+}
+
+// errCheckedAfter reports whether rows.Err() is called in a block reachable from iteration
+func (u *rowsUsage) errCheckedAfter(next *ssa.Call) bool {
+	reachable := make(map[*ssa.BasicBlock]bool)
+	queue := []*ssa.BasicBlock{next.Block()}
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		for _, succ := range block.Succs {
+			if !reachable[succ] {
+				reachable[succ] = true
+				queue = append(queue, succ)
+			}
+		}
+	}
+
+	for _, check := range u.errChecks {
+		if reachable[check.Block()] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+package execinquery
+
+import "strings"
+
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // keyword or bare identifier
+	tokenIdent                   // quoted identifier
+	tokenString                  // string literal, including dollar-quoted one
+	tokenNumber                  // numeric literal
+	tokenParam                   // placeholder: ?, $1, :name or @name
+	tokenPunct                   // operator or punctuation
+)
+
+// sqlToken is a lexical token of SQL query
+type sqlToken struct {
+	kind tokenKind
+	text string
+	// depth is a parentheses nesting level of token
+	depth int
+	// offset is a byte offset of token in query
+	offset int
+}
+
+func (t sqlToken) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// tokenize splits query into tokens according to given dialect, skipping whitespaces and comments
+func tokenize(query string, d *dialect) []sqlToken {
+	var tokens []sqlToken
+	depth := 0
+
+	emit := func(kind tokenKind, from, to int) {
+		tokens = append(tokens, sqlToken{kind: kind, text: query[from:to], depth: depth, offset: from})
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		next := byte(0)
+		if i+1 < len(query) {
+			next = query[i+1]
+		}
+
+		switch {
+		case isSpace(c):
+			i++
+		case c == '-' && next == '-', c == '#' && d.hashComments:
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				return tokens
+			}
+			i += end + 1
+		case c == '/' && next == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				return tokens
+			}
+			i += end + 4
+		case c == '\'':
+			end := scanQuoted(query, i, '\'', d.backslashEscapes)
+			emit(tokenString, i, end)
+			i = end
+		case c == '"':
+			if d.doubleQuotedStrings {
+				end := scanQuoted(query, i, '"', d.backslashEscapes)
+				emit(tokenString, i, end)
+				i = end
+			} else {
+				end := scanQuoted(query, i, '"', false)
+				emit(tokenIdent, i, end)
+				i = end
+			}
+		case c == '`' && d.backtickIdents:
+			end := scanQuoted(query, i, '`', false)
+			emit(tokenIdent, i, end)
+			i = end
+		case c == '[' && d.bracketIdents:
+			end := scanQuoted(query, i, ']', false)
+			emit(tokenIdent, i, end)
+			i = end
+		case c == '$' && isDigit(next):
+			end := scanWhile(query, i+1, isDigit)
+			emit(tokenParam, i, end)
+			i = end
+		case c == '$' && d.dollarQuotes && (next == '$' || isIdentStart(next)):
+			end, ok := scanDollarQuoted(query, i)
+			if !ok {
+				emit(tokenPunct, i, i+1)
+				i++
+				continue
+			}
+			emit(tokenString, i, end)
+			i = end
+		case c == '?' && !d.numberedParams:
+			emit(tokenParam, i, i+1)
+			i++
+		case c == ':' && next == ':':
+			// PostgreSQL type cast
+			emit(tokenPunct, i, i+2)
+			i += 2
+		case (c == ':' || c == '@' && !d.atVariables) && !d.numberedParams && isIdentStart(next):
+			end := scanWhile(query, i+1, isIdentPart)
+			emit(tokenParam, i, end)
+			i = end
+		case isIdentStart(c):
+			end := scanWhile(query, i, isIdentPart)
+			emit(tokenWord, i, end)
+			i = end
+		case isDigit(c):
+			end := scanWhile(query, i, func(c byte) bool {
+				return isDigit(c) || c == '.' || c == 'e' || c == 'E'
+			})
+			emit(tokenNumber, i, end)
+			i = end
+		case c == '(':
+			emit(tokenPunct, i, i+1)
+			depth++
+			i++
+		case c == ')':
+			if depth > 0 {
+				depth--
+			}
+			emit(tokenPunct, i, i+1)
+			i++
+		default:
+			emit(tokenPunct, i, i+1)
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// scanQuoted returns end offset of quoted literal started at i.
+// Closing quote is escaped by doubling it and optionally by backslash.
+func scanQuoted(s string, i int, closing byte, backslashEscapes bool) int {
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case backslashEscapes && s[j] == '\\':
+			j++
+		case s[j] == closing:
+			if j+1 < len(s) && s[j+1] == closing && closing != ']' {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// scanDollarQuoted returns end offset of PostgreSQL dollar-quoted string started at i
+func scanDollarQuoted(s string, i int) (int, bool) {
+	tagEnd := scanWhile(s, i+1, func(c byte) bool {
+		return isIdentStart(c) || isDigit(c)
+	})
+	if tagEnd >= len(s) || s[tagEnd] != '$' {
+		return 0, false
+	}
+
+	tag := s[i : tagEnd+1]
+	end := strings.Index(s[tagEnd+1:], tag)
+	if end == -1 {
+		return len(s), true
+	}
+	return tagEnd + 1 + end + len(tag), true
+}
+
+func scanWhile(s string, i int, fn func(byte) bool) int {
+	for i < len(s) && fn(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '$'
+}
+
+// statement is a first statement of SQL query
+type statement struct {
+	tokens []sqlToken
+	// cmd is a main command of statement, e.g. SELECT for WITH ... SELECT query
+	cmd sqlToken
+}
+
+var statementCommands = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "REPLACE", "UPSERT", "VALUES", "TABLE",
+}
+
+// parseStatement finds main command of first statement of query
+func parseStatement(query string, d *dialect) (statement, bool) {
+	tokens := tokenize(query, d)
+	for i, t := range tokens {
+		if t.kind == tokenPunct && t.text == ";" && t.depth == 0 {
+			tokens = tokens[:i]
+			break
+		}
+	}
+
+	first := -1
+	for i, t := range tokens {
+		if t.kind == tokenWord {
+			first = i
+			break
+		}
+	}
+	if first == -1 {
+		return statement{}, false
+	}
+
+	stmt := statement{tokens: tokens, cmd: tokens[first]}
+	if !stmt.cmd.is("WITH") {
+		return stmt, true
+	}
+
+	// main command of query with common table expressions
+	// is the first one outside of their definitions
+	for _, t := range tokens[first+1:] {
+		if t.depth != stmt.cmd.depth || t.kind != tokenWord {
+			continue
+		}
+		for _, cmd := range statementCommands {
+			if t.is(cmd) {
+				stmt.cmd = t
+				return stmt, true
+			}
+		}
+	}
+
+	return statement{}, false
+}
+
+// returnsRows reports whether statement returns rows in given dialect
+func (s statement) returnsRows(d *dialect) bool {
+	if d.isRowCommand(s.cmd.text) {
+		return true
+	}
+
+	if !d.returning {
+		return false
+	}
+
+	for _, t := range s.tokens {
+		if t.depth == s.cmd.depth && t.offset > s.cmd.offset && t.is("RETURNING") {
+			return true
+		}
+	}
+	return false
+}
+
+// readOnly reports whether statement has no side effects:
+// it neither locks rows (FOR UPDATE/FOR SHARE), nor modifies data in CTE,
+// nor creates table with SELECT INTO, nor calls functions without reading any table
+// (e.g. SELECT pg_advisory_lock(1)).
+func (s statement) readOnly() bool {
+	if !s.cmd.is("SELECT") {
+		return true
+	}
+
+	hasFrom := false
+	for i, t := range s.tokens {
+		if t.kind != tokenWord {
+			continue
+		}
+
+		switch strings.ToUpper(t.text) {
+		case "FROM":
+			hasFrom = true
+		case "INSERT", "UPDATE", "DELETE", "MERGE", "INTO":
+			// data-modifying CTE, SELECT INTO or FOR UPDATE/FOR NO KEY UPDATE clause
+			return false
+		case "SHARE":
+			// FOR SHARE, FOR KEY SHARE or LOCK IN SHARE MODE clause
+			if i > 0 && (s.tokens[i-1].is("FOR") || s.tokens[i-1].is("KEY") || s.tokens[i-1].is("IN")) {
+				return false
+			}
+		}
+	}
+
+	return hasFrom
+}
@@ -0,0 +1,60 @@
+package execinquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatement(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		dialect     *dialect
+		cmd         string
+		returnsRows bool
+	}{
+		{"select", "SELECT * FROM users", dialectGeneric, "SELECT", true},
+		{"lowercase", "delete from users", dialectGeneric, "delete", false},
+		{"line_comment", "-- comment\nUPDATE users SET a = 1", dialectGeneric, "UPDATE", false},
+		{"block_comment", "/* SELECT */ DELETE FROM users", dialectGeneric, "DELETE", false},
+		{"parenthesized", "((SELECT 1)) UNION (SELECT 2)", dialectGeneric, "SELECT", true},
+		{"cte_select", "WITH a AS (DELETE FROM t RETURNING id) SELECT * FROM a", dialectGeneric, "SELECT", true},
+		{"cte_insert", "WITH a(x) AS (SELECT 1) INSERT INTO t SELECT x FROM a", dialectGeneric, "INSERT", false},
+		{"returning", "INSERT INTO t VALUES (1) RETURNING id", dialectPostgres, "INSERT", true},
+		{"returning_in_string", "INSERT INTO t VALUES ('RETURNING')", dialectPostgres, "INSERT", false},
+		{"returning_in_ident", `UPDATE t SET "RETURNING" = 1`, dialectPostgres, "UPDATE", false},
+		{"returning_in_subquery", "DELETE FROM t WHERE id IN (SELECT id FROM f(1) RETURNING)", dialectPostgres, "DELETE", false},
+		{"returning_in_dollar_quotes", "UPDATE t SET a = $q$ RETURNING $q$", dialectPostgres, "UPDATE", false},
+		{"returning_mysql", "INSERT INTO t VALUES (1) RETURNING id", dialectMySQL, "INSERT", false},
+		{"hash_comment_mysql", "# SELECT\nDELETE FROM t", dialectMySQL, "DELETE", false},
+		{"backslash_generic", `UPDATE t SET a = 'it\'s RETURNING'`, dialectGeneric, "UPDATE", true},
+		{"backslash_sqlite", `UPDATE t SET a = 'it\'s RETURNING'`, dialectSQLite, "UPDATE", true},
+		{"bracket_sqlite", "UPDATE [RETURNING] SET a = 1", dialectSQLite, "UPDATE", false},
+		{"pragma_sqlite", "PRAGMA table_info(t)", dialectSQLite, "PRAGMA", true},
+		{"pragma_postgres", "PRAGMA table_info(t)", dialectPostgres, "PRAGMA", false},
+		{"exists_clickhouse", "EXISTS TABLE t", dialectClickHouse, "EXISTS", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stmt, ok := parseStatement(tc.query, tc.dialect)
+			assert.True(t, ok)
+			assert.Equal(t, tc.cmd, stmt.cmd.text)
+			assert.Equalf(t, tc.returnsRows, stmt.returnsRows(tc.dialect), "query: %s", tc.query)
+		})
+	}
+}
+
+func TestTokenizeParams(t *testing.T) {
+	tokens := tokenize("SELECT a::int, :name, @arg, $2, ? FROM t WHERE b = '?' -- ?", dialectGeneric)
+
+	var params []string
+	for _, token := range tokens {
+		if token.kind == tokenParam {
+			params = append(params, token.text)
+		}
+	}
+
+	assert.Equal(t, []string{":name", "@arg", "$2", "?"}, params)
+}
@@ -0,0 +1,73 @@
+package b
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+)
+
+type User struct {
+	ID int
+}
+
+func databaseSQL(ctx context.Context, tx *sql.Tx, conn *sql.Conn) {
+	_, _ = tx.Query("DELETE FROM users WHERE id=?", 1)                   // want "Use Exec instead of Query to execute `DELETE` query"
+	_ = tx.QueryRowContext(ctx, "UPDATE users SET name=? WHERE id=?", 1) // want "Use ExecContext instead of QueryRowContext to execute `UPDATE` query"
+	_, _ = conn.QueryContext(ctx, "DELETE FROM users WHERE id=?", 1)     // want "Use ExecContext instead of QueryContext to execute `DELETE` query"
+	_, _ = conn.QueryContext(ctx, "SELECT * FROM users WHERE id=?", 1)
+}
+
+func preparedStatements(ctx context.Context, db *sql.DB) {
+	stmt, _ := db.Prepare("DELETE FROM users WHERE id=?")
+	_, _ = stmt.Query(1) // want "Use Exec instead of Query to execute `DELETE` query"
+
+	stmtCtx, _ := db.PrepareContext(ctx, "UPDATE users SET name=?")
+	_ = stmtCtx.QueryRowContext(ctx, "alice") // want "Use ExecContext instead of QueryRowContext to execute `UPDATE` query"
+
+	selectStmt, _ := db.Prepare("SELECT * FROM users WHERE id=?")
+	_, _ = selectStmt.Query(1)
+}
+
+func sqlxMethods(ctx context.Context, db *sqlx.DB, tx *sqlx.Tx) {
+	var users []User
+	var user User
+
+	_, _ = db.Queryx("DELETE FROM users WHERE id=?", 1)                   // want "Use Exec instead of Queryx to execute `DELETE` query"
+	_ = db.QueryRowxContext(ctx, "UPDATE users SET name=? WHERE id=?", 1) // want "Use ExecContext instead of QueryRowxContext to execute `UPDATE` query"
+	_ = db.Select(&users, "DELETE FROM users")                            // want "Use Exec instead of Select to execute `DELETE` query"
+	_ = db.GetContext(ctx, &user, "UPDATE users SET id=1")                // want "Use ExecContext instead of GetContext to execute `UPDATE` query"
+	_, _ = db.NamedQuery("INSERT INTO users (id) VALUES (:id)", user)     // want "Use NamedExec instead of NamedQuery to execute `INSERT` query"
+	_ = tx.Get(&user, "DELETE FROM users WHERE id=?", 1)                  // want "Use Exec instead of Get to execute `DELETE` query"
+	_ = db.Select(&users, "SELECT * FROM users")
+
+	// methods of embedded *sql.DB
+	_, _ = db.Query("DELETE FROM users WHERE id=?", 1) // want "Use Exec instead of Query to execute `DELETE` query"
+
+	stmt, _ := db.Preparex("DELETE FROM users WHERE id=?")
+	_, _ = stmt.Query(1) // want "Use Exec instead of Query to execute `DELETE` query"
+}
+
+func pgxMethods(ctx context.Context, conn *pgx.Conn, tx pgx.Tx, pool *pgxpool.Pool) {
+	_, _ = conn.Query(ctx, "DELETE FROM users WHERE id=$1", 1)      // want "Use Exec instead of Query to execute `DELETE` query"
+	_ = tx.QueryRow(ctx, "UPDATE users SET name=$1 WHERE id=$2", 1) // want "Use Exec instead of QueryRow to execute `UPDATE` query"
+	_, _ = pool.Query(ctx, "INSERT INTO users (id) VALUES ($1)", 1) // want "Use Exec instead of Query to execute `INSERT` query"
+	_, _ = pool.Query(ctx, "SELECT * FROM users")
+	_, _ = conn.Exec(ctx, "DELETE FROM users")
+}
+
+// Repo is in-house wrapper of database connection
+type Repo struct {
+	*sql.DB
+}
+
+type UserRepo struct {
+	Repo
+}
+
+func wrappers(repo *UserRepo) {
+	_, _ = repo.Query("DELETE FROM users WHERE id=?", 1) // want "Use Exec instead of Query to execute `DELETE` query"
+	_ = repo.QueryRow("SELECT * FROM users WHERE id=?", 1)
+}
@@ -0,0 +1,42 @@
+package b
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+func execSelect(ctx context.Context, db *sql.DB, tx *sql.Tx) {
+	_, _ = db.Exec("SELECT * FROM users WHERE id=?", 1)                // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+	_, _ = db.ExecContext(ctx, "SHOW tables")                          // want "Use QueryContext or QueryRowContext instead of ExecContext to execute `SHOW` query"
+	_, _ = tx.ExecContext(ctx, "VALUES (1, 'one'), (2, 'two')")        // want "Use QueryContext or QueryRowContext instead of ExecContext to execute `VALUES` query"
+	_, _ = db.Exec("/* get users */ SELECT id, name FROM users")       // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+	_, _ = db.Exec("WITH u AS (SELECT id FROM users) SELECT * FROM u") // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+
+	// statements with side effects are fine
+	_, _ = db.Exec("DELETE FROM users WHERE id=?", 1)
+	_, _ = tx.Exec("SELECT * FROM users WHERE id=? FOR UPDATE", 1)
+	_, _ = tx.Exec("SELECT * FROM users WHERE id=? FOR NO KEY UPDATE", 1)
+	_, _ = tx.Exec("SELECT * FROM users WHERE id=? FOR SHARE", 1)
+	_, _ = tx.Exec("SELECT * FROM users WHERE id=? LOCK IN SHARE MODE", 1)
+	_, _ = db.Exec("SELECT pg_advisory_lock(1)")
+	_, _ = db.Exec("SELECT * INTO users_backup FROM users")
+	_, _ = db.Exec("WITH d AS (DELETE FROM users RETURNING id) SELECT count(*) FROM d")
+	_, _ = db.Exec("WITH u AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM u)")
+}
+
+func execPrepared(ctx context.Context, db *sql.DB) {
+	stmt, _ := db.PrepareContext(ctx, "SELECT * FROM users WHERE id=?")
+	_, _ = stmt.ExecContext(ctx, 1) // want "Use QueryContext or QueryRowContext instead of ExecContext to execute `SELECT` query"
+
+	deleteStmt, _ := db.Prepare("DELETE FROM users WHERE id=?")
+	_, _ = deleteStmt.Exec(1)
+}
+
+func execDrivers(ctx context.Context, db *sqlx.DB, conn *pgx.Conn) {
+	_, _ = db.Exec("SELECT * FROM users")                       // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+	_ = db.MustExec("SELECT * FROM users")                      // want "Use Query or QueryRow instead of MustExec to execute `SELECT` query"
+	_, _ = conn.Exec(ctx, "SELECT * FROM users WHERE id=$1", 1) // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+}
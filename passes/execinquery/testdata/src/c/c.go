@@ -0,0 +1,25 @@
+package c
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Storage is in-house database wrapper with its own query methods
+type Storage struct {
+	db *sql.DB
+}
+
+func (s *Storage) Fetch(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, query, args...)
+}
+
+type UserStorage struct {
+	*Storage
+}
+
+func custom(ctx context.Context, s *Storage, us UserStorage) {
+	_, _ = s.Fetch(ctx, "DELETE FROM users WHERE id=?", 1) // want "Use Exec instead of Fetch to execute `DELETE` query"
+	_, _ = us.Fetch(ctx, "UPDATE users SET name=?", "bob") // want "Use Exec instead of Fetch to execute `UPDATE` query"
+	_, _ = s.Fetch(ctx, "SELECT * FROM users")
+}
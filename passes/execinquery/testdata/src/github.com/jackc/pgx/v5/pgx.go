@@ -0,0 +1,48 @@
+package pgx
+
+import "context"
+
+type Rows interface {
+	Close()
+	Err() error
+	Next() bool
+	Scan(dest ...any) error
+}
+
+type Row interface {
+	Scan(dest ...any) error
+}
+
+type CommandTag struct{}
+
+type Conn struct{}
+
+func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error) {
+	panic("not implemented")
+}
+
+func (c *Conn) QueryRow(ctx context.Context, sql string, args ...any) Row {
+	panic("not implemented")
+}
+
+func (c *Conn) Exec(ctx context.Context, sql string, args ...any) (CommandTag, error) {
+	panic("not implemented")
+}
+
+type Tx interface {
+	Query(ctx context.Context, sql string, args ...any) (Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) Row
+	Exec(ctx context.Context, sql string, args ...any) (CommandTag, error)
+}
+
+type NamedArgs map[string]any
+
+type QueryExecMode int
+
+const QueryExecModeSimpleProtocol QueryExecMode = 5
+
+type Identifier []string
+
+func (ident Identifier) Sanitize() string {
+	panic("not implemented")
+}
@@ -0,0 +1,21 @@
+package pgxpool
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Pool struct{}
+
+func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	panic("not implemented")
+}
+
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	panic("not implemented")
+}
+
+func (p *Pool) Exec(ctx context.Context, sql string, args ...any) (pgx.CommandTag, error) {
+	panic("not implemented")
+}
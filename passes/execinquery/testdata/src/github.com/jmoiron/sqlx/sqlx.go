@@ -0,0 +1,96 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DB struct {
+	*sql.DB
+}
+
+type Tx struct {
+	*sql.Tx
+}
+
+type Conn struct {
+	*sql.Conn
+}
+
+type Stmt struct {
+	*sql.Stmt
+}
+
+type Rows struct {
+	*sql.Rows
+}
+
+type Row struct{}
+
+func (r *Row) Scan(dest ...any) error {
+	panic("not implemented")
+}
+
+func (db *DB) Queryx(query string, args ...any) (*Rows, error) {
+	panic("not implemented")
+}
+
+func (db *DB) QueryxContext(ctx context.Context, query string, args ...any) (*Rows, error) {
+	panic("not implemented")
+}
+
+func (db *DB) QueryRowx(query string, args ...any) *Row {
+	panic("not implemented")
+}
+
+func (db *DB) QueryRowxContext(ctx context.Context, query string, args ...any) *Row {
+	panic("not implemented")
+}
+
+func (db *DB) Select(dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (db *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (db *DB) Get(dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (db *DB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (db *DB) NamedQuery(query string, arg any) (*Rows, error) {
+	panic("not implemented")
+}
+
+func (db *DB) NamedExec(query string, arg any) (sql.Result, error) {
+	panic("not implemented")
+}
+
+func (db *DB) Preparex(query string) (*Stmt, error) {
+	panic("not implemented")
+}
+
+func (tx *Tx) Queryx(query string, args ...any) (*Rows, error) {
+	panic("not implemented")
+}
+
+func (tx *Tx) Select(dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (tx *Tx) Get(dest any, query string, args ...any) error {
+	panic("not implemented")
+}
+
+func (s *Stmt) Queryx(args ...any) (*Rows, error) {
+	panic("not implemented")
+}
+
+func (db *DB) MustExec(query string, args ...any) sql.Result {
+	panic("not implemented")
+}
@@ -0,0 +1,9 @@
+package pq
+
+func QuoteIdentifier(name string) string {
+	panic("not implemented")
+}
+
+func QuoteLiteral(literal string) string {
+	panic("not implemented")
+}
@@ -0,0 +1,112 @@
+package injection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var table = "users"
+
+func concat(db *sql.DB, name string, id int) {
+	_, _ = db.Query("SELECT * FROM users WHERE name = '" + name + "'") // want "SQL query passed to Query is built from non-constant data, use query arguments instead"
+	_, _ = db.Query("SELECT * FROM users WHERE id = " + strconv.Itoa(id))
+	_, _ = db.Query("SELECT * FROM "+table+" WHERE name = $1", name)
+	_, _ = db.Query("SELECT * FROM " + pq.QuoteIdentifier(name))
+
+	q := "SELECT * FROM users"
+	if name != "" {
+		q += " WHERE name = '" + name + "'"
+	}
+	_, _ = db.Query(q) // want "SQL query passed to Query is built from non-constant data"
+
+	safe := "SELECT * FROM users"
+	if id > 0 {
+		safe += " WHERE id = $1"
+	}
+	_, _ = db.Query(safe, id)
+}
+
+func sprintf(ctx context.Context, db *sql.DB, name string, id int) {
+	_, _ = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", name))                  // want "SQL query passed to ExecContext is built from non-constant data"
+	_, _ = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM users WHERE id = %v", name)) // want "SQL query passed to ExecContext is built from non-constant data"
+	_, _ = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM users WHERE id = %d", id))
+	_, _ = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = %d", table, id))
+	_, _ = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", pgx.Identifier{name}.Sanitize()))
+}
+
+func builder(db *sql.DB, columns []string, name string) {
+	var b strings.Builder
+	b.WriteString("SELECT id FROM users WHERE name = ")
+	b.WriteString(name)
+	_, _ = db.Query(b.String()) // want "SQL query passed to Query is built from non-constant data"
+
+	var c strings.Builder
+	c.WriteString("SELECT id FROM users")
+	fmt.Fprintf(&c, " LIMIT %d", 10)
+	_, _ = db.Query(c.String())
+
+	_, _ = db.Query("SELECT " + strings.Join(columns, ", ") + " FROM users") // want "SQL query passed to Query is built from non-constant data"
+	_, _ = db.Query("SELECT " + strings.Join([]string{"id", "name"}, ", ") + " FROM users")
+}
+
+func passthrough(db *sql.DB, query string) {
+	_, _ = db.Query(query)
+}
+
+func prepare(db *sqlx.DB, name string) {
+	_, _ = db.Preparex("SELECT * FROM " + name) // want "SQL query passed to Preparex is built from non-constant data"
+}
+
+func iface(ctx context.Context, tx pgx.Tx, name string) {
+	_, _ = tx.Exec(ctx, "DELETE FROM "+name) // want "SQL query passed to Exec is built from non-constant data"
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func customQuote(db *sql.DB, name string) {
+	_, _ = db.Query("SELECT * FROM " + quoteIdent(name))
+}
+
+func verbs(db *sql.DB, name string, id int) {
+	_, _ = db.Query(fmt.Sprintf("SELECT * FROM users WHERE name = x'%x'", name)) // want "SQL query passed to Query is built from non-constant data"
+	_, _ = db.Query(fmt.Sprintf("SELECT * FROM users WHERE id = %x", id))
+}
+
+func tableName() string {
+	return "users"
+}
+
+func withSuffix(table, suffix string) string {
+	return table + "_" + suffix
+}
+
+func calls(db *sql.DB, name string) {
+	_, _ = db.Query("SELECT * FROM " + tableName())
+	_, _ = db.Query("SELECT * FROM " + withSuffix("users", "archive"))
+	_, _ = db.Query("SELECT * FROM " + withSuffix("users", name)) // want "SQL query passed to Query is built from non-constant data"
+	_, _ = db.Query("SELECT * FROM " + strings.ToLower("USERS"))
+	_, _ = db.Query("SELECT * FROM " + strings.ToLower(name)) // want "SQL query passed to Query is built from non-constant data"
+}
+
+func ignore(string) string {
+	return "users"
+}
+
+func reused(db *sql.DB, name string) {
+	_, _ = db.Query("SELECT * FROM " + ignore(name) + withSuffix("x", name)) // want "SQL query passed to Query is built from non-constant data"
+	_, _ = db.Query("SELECT * FROM " + ignore(name) + ignore(name))
+}
+
+func noArgs(db *sql.DB) {
+	_, _ = db.Query(fmt.Sprintf("SELECT * FROM users"))
+	_, _ = db.Query(fmt.Sprint())
+}
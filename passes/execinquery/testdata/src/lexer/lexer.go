@@ -0,0 +1,33 @@
+package lexer
+
+import (
+	"context"
+	"database/sql"
+)
+
+func cte(ctx context.Context, db *sql.DB) {
+	_, _ = db.QueryContext(ctx, "WITH x AS (SELECT id FROM users) INSERT INTO archive SELECT * FROM x") // want "Use ExecContext instead of QueryContext to execute `INSERT` query"
+	_, _ = db.QueryContext(ctx, "WITH RECURSIVE t(n) AS (VALUES (1) UNION ALL SELECT n+1 FROM t) SELECT n FROM t")
+	_, _ = db.QueryContext(ctx, "WITH d AS (DELETE FROM users RETURNING id) SELECT count(*) FROM d")
+	_, _ = db.QueryContext(ctx, "WITH x AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM x) RETURNING id")
+}
+
+func comments(db *sql.DB) {
+	_, _ = db.Query("/* hint */ UPDATE users SET name = 'bob'") // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Query("-- select users\nDELETE FROM users")       // want "Use Exec instead of Query to execute `DELETE` query"
+	_, _ = db.Query("/*+ INDEX(users) */ SELECT * FROM users")
+}
+
+func parenthesized(db *sql.DB) {
+	_, _ = db.Query("(SELECT id FROM users) UNION (SELECT id FROM admins)")
+}
+
+func quoted(db *sql.DB) {
+	_, _ = db.Query("UPDATE users SET note = 'RETURNING' WHERE id = 1")     // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Query(`UPDATE users SET "returning" = 1 WHERE id = 1`)        // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Query("UPDATE users SET note = $$ RETURNING $$ WHERE id = 1") // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Query("UPDATE users SET note = $tag$ it's RETURNING $tag$ RETURNING id")
+	_, _ = db.Query("UPDATE users SET note = 'it''s' RETURNING id")
+	_, _ = db.Query("INSERT INTO users (note) VALUES ('-- not a comment') RETURNING id")
+	_, _ = db.Query(`SELECT "UPDATE" FROM users`)
+}
@@ -0,0 +1,14 @@
+package mysql
+
+import (
+	"database/sql"
+)
+
+func dialect(db *sql.DB) {
+	_, _ = db.Query("INSERT INTO users (name) VALUES (?) RETURNING id") // want "Use Exec instead of Query to execute `INSERT` query"
+	_, _ = db.Query("# get users\nDESCRIBE users")
+	_, _ = db.Query(`SELECT * FROM users WHERE name = "it\"s UPDATE"`)
+	_, _ = db.Query("CALL get_users()")
+	_, _ = db.Query("PRAGMA table_info(users)") // want "Use Exec instead of Query to execute `PRAGMA` query"
+	_, _ = db.Exec("SELECT * FROM users")       // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+}
@@ -0,0 +1,63 @@
+package placeholders
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+const selectByID = "SELECT * FROM users WHERE id = $1"
+
+func positional(db *sql.DB) {
+	_, _ = db.Query("SELECT * FROM users WHERE id = ? AND name = ?", 1, "bob")
+	_, _ = db.Query("SELECT * FROM users WHERE id = ? AND name = ?", 1) // want "Not enough arguments in call to Query: query has 2 placeholders, but 1 arguments given"
+	_, _ = db.Exec("DELETE FROM users WHERE id = ?", 1, 2)              // want "Too many arguments in call to Exec: query has 1 placeholders, but 2 arguments given"
+	_ = db.QueryRow("SELECT * FROM users WHERE note = '?' AND id = ?", 1)
+	_, _ = db.Exec("DELETE FROM users -- WHERE id = ?")
+	_, _ = db.Exec("DELETE FROM users WHERE id = ?", "x", 1) // want "Too many arguments in call to Exec"
+}
+
+func numbered(ctx context.Context, db *sql.DB, id int) {
+	_, _ = db.QueryContext(ctx, selectByID, id)
+	_, _ = db.QueryContext(ctx, selectByID) // want "Not enough arguments in call to QueryContext: query has 1 placeholders, but 0 arguments given"
+	_, _ = db.ExecContext(ctx, "UPDATE users SET name = $1, note = $1 WHERE id = $2", "bob", id)
+	_, _ = db.ExecContext(ctx, "UPDATE users SET name = $1 WHERE id = $3", "bob", 0, id) // want "Placeholder \\$2 is missing in query passed to ExecContext"
+	_, _ = db.ExecContext(ctx, "UPDATE users SET name = $2 WHERE id = $1::int", id)      // want "Not enough arguments in call to ExecContext: query has 2 placeholders, but 1 arguments given"
+}
+
+func named(db *sql.DB) {
+	_, _ = db.Exec("DELETE FROM users WHERE id = @id", sql.Named("id", 1))
+	_, _ = db.Exec("DELETE FROM users WHERE id = @id AND name = @name", sql.Named("id", 1)) // want "Not enough arguments in call to Exec"
+	_, _ = db.Exec("DELETE FROM users WHERE id = @id", 1)
+}
+
+func spread(db *sql.DB, args []any) {
+	_, _ = db.Query("SELECT * FROM users WHERE id = ?", args...)
+}
+
+func unknown(db *sql.DB, query string) {
+	_, _ = db.Query(query, 1, 2, 3)
+}
+
+func mixed(db *sql.DB) {
+	_, _ = db.Query("SELECT * FROM users WHERE id = ? AND name = $1", 1)
+}
+
+func prepared(ctx context.Context, db *sql.DB) {
+	stmt, _ := db.PrepareContext(ctx, "SELECT * FROM users WHERE id = ? AND name = ?")
+	_, _ = stmt.QueryContext(ctx, 1, "bob")
+	_, _ = stmt.QueryContext(ctx, 1) // want "Not enough arguments in call to QueryContext"
+	_, _ = stmt.Exec()               // want "Not enough arguments in call to Exec"
+}
+
+func libraries(ctx context.Context, db *sqlx.DB, conn *pgx.Conn) {
+	var ids []int
+	_ = db.Select(&ids, "SELECT id FROM users WHERE name = ?", "bob")
+	_ = db.Select(&ids, "SELECT id FROM users WHERE name = ?") // want "Not enough arguments in call to Select"
+
+	_, _ = conn.Exec(ctx, "DELETE FROM users WHERE id = $1", 1, 2) // want "Too many arguments in call to Exec"
+	_, _ = conn.Exec(ctx, "DELETE FROM users WHERE id = $1", pgx.QueryExecModeSimpleProtocol, 1)
+	_, _ = conn.Exec(ctx, "DELETE FROM users WHERE id = @id", pgx.NamedArgs{"id": 1})
+}
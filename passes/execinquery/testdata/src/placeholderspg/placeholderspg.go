@@ -0,0 +1,13 @@
+package placeholderspg
+
+import (
+	"database/sql"
+)
+
+func jsonb(db *sql.DB, key string) {
+	_, _ = db.Query("SELECT * FROM t WHERE data ? 'k'")
+	_, _ = db.Query("SELECT * FROM t WHERE data ?| array['a', 'b'] AND id = $1", 1)
+	_, _ = db.Query("SELECT * FROM t WHERE data ? $1", key)
+	_, _ = db.Query("SELECT * FROM t WHERE data ? $1")       // want "Not enough arguments in call to Query: query has 1 placeholders, but 0 arguments given"
+	_, _ = db.Query("SELECT * FROM t WHERE data ? 'k'", key) // want "Too many arguments in call to Query: query has 0 placeholders, but 1 arguments given"
+}
@@ -0,0 +1,16 @@
+package queries // want package:"strings\\(DeleteUsers, InsertUser, UpdateUsers\\)"
+
+const UpdateUsers = "UPDATE users SET name = $1"
+
+var (
+	DeleteUsers = "DELETE FROM " + table
+	InsertUser  = "INSERT INTO " + table + " (name) VALUES ($1)"
+	SelectUsers = "SELECT * FROM " + table
+	selectAll   = "SELECT * FROM " + table
+)
+
+var table = "users"
+
+func init() {
+	SelectUsers += " LIMIT 10"
+}
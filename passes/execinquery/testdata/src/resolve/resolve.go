@@ -0,0 +1,111 @@
+package resolve
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"queries"
+)
+
+const base = "DELETE FROM users"
+
+const byID = base + " WHERE id = $1"
+
+func imported(db *sql.DB) {
+	_, _ = db.Query(queries.UpdateUsers) // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Query(queries.DeleteUsers) // want "Use Exec instead of Query to execute `DELETE` query"
+	_, _ = db.Exec(queries.SelectUsers)
+}
+
+func constants(db *sql.DB) {
+	_, _ = db.Query(byID) // want "Use Exec instead of Query to execute `DELETE` query"
+
+	const local = "UPDATE " + "users"
+	_, _ = db.Query(local + " SET name = $1") // want "Use Exec instead of Query to execute `UPDATE` query"
+}
+
+func sprintf(db *sql.DB, table string) {
+	_, _ = db.Query(fmt.Sprintf("DELETE FROM %s WHERE id = %d", "users", 1)) // want "Use Exec instead of Query to execute `DELETE` query"
+	_, _ = db.Query(fmt.Sprintf("DELETE FROM %s", table))
+}
+
+func join(db *sql.DB) {
+	q := strings.Join([]string{"UPDATE users", "SET name = $1"}, " ")
+	_, _ = db.Query(q) // want "Use Exec instead of Query to execute `UPDATE` query"
+}
+
+func builder(db *sql.DB) {
+	var b strings.Builder
+	b.WriteString("DELETE FROM users")
+	b.WriteByte(' ')
+	fmt.Fprintf(&b, "WHERE id = %d", 1)
+	_, _ = db.Query(b.String()) // want "Use Exec instead of Query to execute `DELETE` query"
+
+	b.Reset()
+	b.WriteString("SELECT * FROM users")
+	_, _ = db.Exec(b.String()) // want "Use Query or QueryRow instead of Exec to execute `SELECT` query"
+
+	p := new(strings.Builder)
+	p.WriteString("UPDATE users SET name = $1")
+	_, _ = db.Query(p.String()) // want "Use Exec instead of Query to execute `UPDATE` query"
+}
+
+func concat(db *sql.DB) {
+	q := "UPDATE users"
+	q += " SET name = $1"
+	_, _ = db.Query(q) // want "Use Exec instead of Query to execute `UPDATE` query"
+}
+
+func conditional(db *sql.DB, returning bool) {
+	q := "UPDATE users SET name = $1"
+	if returning {
+		q += " RETURNING id"
+	}
+	_, _ = db.Query(q)
+
+	var b strings.Builder
+	b.WriteString("DELETE FROM users")
+	for range 2 {
+		b.WriteString(" RETURNING id")
+	}
+	_, _ = db.Query(b.String())
+}
+
+var columns = []string{"id", "name"}
+
+var mutable = []string{"id", "name"}
+
+func init() {
+	mutable[0] = "DELETE"
+}
+
+func joinGlobal(db *sql.DB) {
+	_, _ = db.Query("UPDATE users SET " + strings.Join(columns, " = $1, ") + " = $2") // want "Use Exec instead of Query to execute `UPDATE` query"
+	_, _ = db.Exec(strings.Join(mutable, " "))
+}
+
+func addressTaken(db *sql.DB, s string) {
+	q := "SELECT * FROM users"
+	_, _ = fmt.Sscan(s, &q)
+	_, _ = db.Exec(q)
+
+	var b strings.Builder
+	b.WriteString("SELECT * FROM users")
+	appendWhere(&b)
+	_, _ = db.Exec(b.String())
+
+	p := new(strings.Builder)
+	p.WriteString("SELECT * FROM users")
+	appendWhere(p)
+	_, _ = db.Exec(p.String())
+
+	var w strings.Builder
+	w.WriteString("SELECT * FROM users")
+	_, _ = w.Write([]byte(" WHERE id = $1"))
+	_, _ = db.Exec(w.String())
+}
+
+func appendWhere(b *strings.Builder) {
+	b.WriteString(" WHERE id = $1")
+}
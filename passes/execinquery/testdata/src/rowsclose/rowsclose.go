@@ -0,0 +1,230 @@
+package rowsclose
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+type User struct {
+	ID int
+}
+
+func deferred(db *sql.DB) ([]int, error) {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func notClosed(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users") // want "Rows returned by Query must be closed on all paths: call Close, defer it or return rows"
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func closedOnSomePaths(ctx context.Context, db *sql.DB, limit int) error {
+	rows, err := db.QueryContext(ctx, "SELECT id FROM users") // want "Rows returned by QueryContext must be closed on all paths"
+	if err != nil {
+		return err
+	}
+	if limit == 0 {
+		return errors.New("no limit")
+	}
+
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
+
+func closedExplicitly(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err == nil {
+		for rows.Next() {
+		}
+		err = rows.Err()
+		_ = rows.Close()
+	}
+	return err
+}
+
+func noErrCheck(db *sql.DB) {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() { // want "Rows iteration with Next must be followed by Err check"
+	}
+}
+
+func returned(db *sql.DB) (*sql.Rows, error) {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func returnedDirectly(db *sql.DB) (*sql.Rows, error) {
+	return db.Query("SELECT id FROM users")
+}
+
+type iterator struct {
+	rows *sql.Rows
+}
+
+func stored(db *sql.DB, it *iterator) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	it.rows = rows
+	return nil
+}
+
+func passed(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	return scanAll(rows)
+}
+
+func scanAll(rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func closure(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func closureWithoutErr(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() { // want "Rows iteration with Next must be followed by Err check"
+	}
+	return nil
+}
+
+func closureReassigned(ctx context.Context, conn *pgx.Conn) error {
+	var rows pgx.Rows
+	rows, err := conn.Query(ctx, "SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer func() { rows.Close() }()
+
+	for rows.Next() { // want "Rows iteration with Next must be followed by Err check"
+	}
+	rows = nil
+	return nil
+}
+
+func closureUsesRows(db *sql.DB) error {
+	rows, err := db.Query("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rows.Err() != nil {
+			_ = rows.Close()
+		}
+	}()
+
+	for rows.Next() {
+	}
+	return nil
+}
+
+func sqlxRows(db *sqlx.DB) error {
+	rows, err := db.Queryx("SELECT id FROM users") // want "Rows returned by Queryx must be closed on all paths"
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() { // want "Rows iteration with Next must be followed by Err check"
+	}
+	return nil
+}
+
+func sqlxRowsClosed(db *sqlx.DB) error {
+	rows, err := db.Queryx("SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func pgxRows(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, "SELECT id FROM users") // want "Rows returned by Query must be closed on all paths"
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func pgxRowsClosed(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, "SELECT id FROM users")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func queryRow(db *sql.DB) error {
+	var id int
+	return db.QueryRow("SELECT id FROM users").Scan(&id)
+}
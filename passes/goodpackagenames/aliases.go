@@ -0,0 +1,97 @@
+package goodpackagenames
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkImportAlias reports aliases, which repeat real name of imported package,
+// and aliases, which aren't justified by name collision
+func checkImportAlias(pass *analysis.Pass, file *ast.File, spec *ast.ImportSpec) {
+	alias := importName(spec)
+	if alias == "" || alias == "_" || alias == "." {
+		return
+	}
+
+	pkgName := pass.TypesInfo.PkgNameOf(spec)
+	if pkgName == nil {
+		return
+	}
+	realName := pkgName.Imported().Name()
+	importPath, _ := strconv.Unquote(spec.Path.Value)
+
+	if alias == realName {
+		// alias of package named differently from its import path is added by goimports on purpose
+		if realName != pathName(importPath) {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     spec.Pos(),
+			Message: fmt.Sprintf("redundant import alias %s, package is already named %s", alias, realName),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: fmt.Sprintf("Remove import alias %s", alias),
+				TextEdits: []analysis.TextEdit{{
+					Pos: spec.Name.Pos(),
+					End: spec.Path.Pos(),
+				}},
+			}},
+		})
+		return
+	}
+
+	// invalid real name can't be used as is
+	if realName != canonicImportName(realName) || nameCollides(pass, file, spec, realName) {
+		return
+	}
+
+	pass.Reportf(spec.Pos(), "unnecessary import alias %s for package %s, its name %s doesn't collide with other names", alias, importPath, realName)
+}
+
+// nameCollides reports whether real name of imported package is used by other import of file,
+// package-level declaration or local declaration of file
+func nameCollides(pass *analysis.Pass, file *ast.File, spec *ast.ImportSpec, realName string) bool {
+	for _, other := range file.Imports {
+		if other == spec {
+			continue
+		}
+
+		otherName := pass.TypesInfo.PkgNameOf(other)
+		if otherName == nil {
+			continue
+		}
+		if otherName.Name() == realName || otherName.Imported().Name() == realName {
+			return true
+		}
+	}
+
+	obj := pass.Pkg.Scope().Lookup(realName)
+	if _, isPkgName := obj.(*types.PkgName); obj != nil && !isPkgName {
+		return true
+	}
+
+	return declaresLocal(pass, file, realName)
+}
+
+// declaresLocal reports whether file has local declaration of name
+func declaresLocal(pass *analysis.Pass, file *ast.File, name string) bool {
+	fileScope := pass.TypesInfo.Scopes[file]
+
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if found || !ok || ident.Name != name {
+			return !found
+		}
+
+		if obj := pass.TypesInfo.Defs[ident]; obj != nil && isLocal(pass, obj, fileScope) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -3,6 +3,7 @@ package goodpackagenames
 import (
 	"go/ast"
 	"go/token"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -20,15 +21,62 @@ See https://go.dev/blog/package-names for more information.
 	packageTestSuffix = "_test"
 )
 
+func init() {
+	Analyzer.Flags.Var(&flagDeny, "deny", "comma-separated list of meaningless package names")
+	Analyzer.Flags.BoolVar(&flagStdlib, "stdlib", false, "report package names colliding with standard library packages")
+	Analyzer.Flags.BoolVar(&flagStutter, "stutter", false, "report exported names starting with package name, e.g. config.ConfigLoader")
+	Analyzer.Flags.Var(&flagStutterExempt, "stutter-exempt", "comma-separated list of patterns of names allowed to stutter, e.g. errors.Err*")
+	Analyzer.Flags.BoolVar(&flagShadowStdlib, "shadow-stdlib", false, "report local names shadowing well-known standard library packages, even if they aren't imported")
+}
+
 var Analyzer = &analysis.Analyzer{
 	Name: Name,
 	Doc:  Doc,
 	Run:  run,
 }
 
+// nameList is a comma-separated list of names
+type nameList []string
+
+func (l *nameList) Set(v string) error {
+	*l = nil
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*l = append(*l, name)
+		}
+	}
+	return nil
+}
+
+func (l nameList) String() string {
+	return strings.Join(l, ",")
+}
+
+var (
+	// flagDeny holds names, which tell nothing about package content
+	flagDeny   = nameList{"util", "utils", "common", "misc", "helper", "helpers", "base", "types"}
+	flagStdlib bool
+
+	flagStutter       bool
+	flagStutterExempt nameList
+
+	flagShadowStdlib bool
+)
+
 func run(pass *analysis.Pass) (any, error) {
+	var std *stdlibIndex
+	if flagStdlib {
+		std = stdlibPackages()
+	}
+
 	for _, file := range pass.Files {
 		checkPackageName(pass, file, packageName(file))
+		checkMeaningfulName(pass, file, packageName(file), std)
+		checkPathName(pass, file, packageName(file))
+		if flagStutter {
+			checkStutter(pass, file)
+		}
+		checkShadowing(pass, file)
 
 		for _, decl := range file.Decls {
 			genDecl, ok := decl.(*ast.GenDecl)
@@ -37,7 +85,7 @@ func run(pass *analysis.Pass) (any, error) {
 			}
 
 			if genDecl.Tok == token.IMPORT {
-				checkImports(pass, genDecl)
+				checkImports(pass, file, genDecl)
 			}
 		}
 	}
@@ -52,7 +100,24 @@ func checkPackageName(pass *analysis.Pass, file *ast.File, packageName string) {
 	}
 }
 
-func checkImports(pass *analysis.Pass, decl *ast.GenDecl) {
+// checkMeaningfulName reports package names from deny-list and names of standard library packages
+func checkMeaningfulName(pass *analysis.Pass, file *ast.File, packageName string, std *stdlibIndex) {
+	name := strings.TrimSuffix(packageName, packageTestSuffix)
+	if name == "main" {
+		return
+	}
+
+	if slices.Contains(flagDeny, name) {
+		pass.Reportf(file.Name.End(), "meaningless package name %s, use name describing what package provides", name)
+		return
+	}
+
+	if importPath, ok := std.collision(pass.Pkg.Path(), name); ok {
+		pass.Reportf(file.Name.End(), "package name %s collides with standard library package %s", name, importPath)
+	}
+}
+
+func checkImports(pass *analysis.Pass, file *ast.File, decl *ast.GenDecl) {
 	for _, spec := range decl.Specs {
 		importSpec, ok := spec.(*ast.ImportSpec)
 		if !ok {
@@ -60,8 +125,14 @@ func checkImports(pass *analysis.Pass, decl *ast.GenDecl) {
 		}
 
 		importName := importName(importSpec)
-		if importName != "" {
-			checkImportName(pass, importSpec, importName)
+		if importName == "" {
+			continue
+		}
+
+		checkImportName(pass, importSpec, importName)
+		// invalid alias is reported already
+		if importName == canonicImportName(importName) {
+			checkImportAlias(pass, file, importSpec)
 		}
 	}
 }
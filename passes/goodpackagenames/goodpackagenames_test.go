@@ -3,12 +3,60 @@ package goodpackagenames_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/analysis/analysistest"
 
 	"golang.yandex/linters/passes/goodpackagenames"
 )
 
+// setFlag sets analyzer flag for the duration of test
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+
+	prev := goodpackagenames.Analyzer.Flags.Lookup(name).Value.String()
+	require.NoError(t, goodpackagenames.Analyzer.Flags.Set(name, value))
+	t.Cleanup(func() {
+		require.NoError(t, goodpackagenames.Analyzer.Flags.Set(name, prev))
+	})
+}
+
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "a/lintexamples")
 }
+
+func TestMeaningfulNames(t *testing.T) {
+	setFlag(t, "stdlib", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "names/...")
+}
+
+func TestDenyFlag(t *testing.T) {
+	setFlag(t, "deny", "manager")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "denied/...")
+}
+
+func TestImportAliases(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, goodpackagenames.Analyzer, "aliases/...")
+}
+
+func TestStutter(t *testing.T) {
+	setFlag(t, "stutter", "true")
+	setFlag(t, "stutter-exempt", "api.APIVersion")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "stutter/...")
+}
+
+func TestShadowing(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "shadow/imported")
+
+	setFlag(t, "shadow-stdlib", "true")
+
+	analysistest.Run(t, testdata, goodpackagenames.Analyzer, "shadow/wellknown")
+}
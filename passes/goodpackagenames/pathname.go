@@ -0,0 +1,33 @@
+package goodpackagenames
+
+import (
+	"go/ast"
+	"path"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPathName reports package names, which differ from the last element of import path
+func checkPathName(pass *analysis.Pass, file *ast.File, packageName string) {
+	name := strings.TrimSuffix(packageName, packageTestSuffix)
+	if name == "main" {
+		return
+	}
+
+	pkgPath := strings.TrimSuffix(pass.Pkg.Path(), packageTestSuffix)
+	expected := pathName(pkgPath)
+	if expected == "" || canonicImportName(name) == canonicImportName(expected) {
+		return
+	}
+
+	pass.Reportf(file.Name.End(), "package name %s doesn't match import path %s, use %s", name, pkgPath, canonicImportName(expected))
+}
+
+// pathName guesses package name from import path: its last element without go- prefix
+// and major version suffix, e.g. redis for github.com/go-redis/redis/v9
+func pathName(importPath string) string {
+	name := path.Base(trimVersionSuffix(importPath))
+	name = strings.TrimPrefix(name, "go-")
+	return strings.NewReplacer("-", "", ".", "").Replace(name)
+}
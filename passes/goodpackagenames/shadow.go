@@ -0,0 +1,71 @@
+package goodpackagenames
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// wellKnownPackages maps names of commonly used standard library packages to their import paths
+var wellKnownPackages = map[string]string{
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"context":  "context",
+	"errors":   "errors",
+	"filepath": "path/filepath",
+	"fmt":      "fmt",
+	"http":     "net/http",
+	"io":       "io",
+	"json":     "encoding/json",
+	"log":      "log",
+	"maps":     "maps",
+	"os":       "os",
+	"path":     "path",
+	"reflect":  "reflect",
+	"regexp":   "regexp",
+	"slices":   "slices",
+	"sort":     "sort",
+	"sql":      "database/sql",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"time":     "time",
+	"url":      "net/url",
+}
+
+// checkShadowing reports local declarations, which names shadow imported packages of file
+func checkShadowing(pass *analysis.Pass, file *ast.File) {
+	fileScope := pass.TypesInfo.Scopes[file]
+	if fileScope == nil {
+		return
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			return true
+		}
+
+		obj := pass.TypesInfo.Defs[ident]
+		if obj == nil || !isLocal(pass, obj, fileScope) {
+			return true
+		}
+
+		if pkgName, ok := fileScope.Lookup(ident.Name).(*types.PkgName); ok {
+			pass.Reportf(ident.Pos(), "%s shadows imported package %s", ident.Name, pkgName.Imported().Path())
+			return true
+		}
+
+		if importPath, ok := wellKnownPackages[ident.Name]; ok && flagShadowStdlib {
+			pass.Reportf(ident.Pos(), "%s shadows name of standard library package %s", ident.Name, importPath)
+		}
+		return true
+	})
+}
+
+// isLocal reports whether object is declared inside function, including parameters and results
+func isLocal(pass *analysis.Pass, obj types.Object, fileScope *types.Scope) bool {
+	parent := obj.Parent()
+	return parent != nil && parent != pass.Pkg.Scope() && parent != fileScope && parent != types.Universe
+}
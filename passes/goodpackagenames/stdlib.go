@@ -0,0 +1,95 @@
+package goodpackagenames
+
+import (
+	"bufio"
+	"bytes"
+	"go/build"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/module"
+)
+
+// stdlibIndex holds standard library packages
+type stdlibIndex struct {
+	// names maps package name to import path of standard library package
+	names map[string]string
+	// paths holds import paths of standard library packages
+	paths map[string]bool
+}
+
+var stdlib struct {
+	once  sync.Once
+	index *stdlibIndex
+}
+
+// stdlibPackages returns standard library packages of Go toolchain, which GOROOT is used for analysis.
+// The go command is run in GOROOT, so it doesn't depend on PATH and module of analyzed package.
+// Nil index is returned if packages can't be listed, so collisions aren't checked.
+func stdlibPackages() *stdlibIndex {
+	stdlib.once.Do(func() {
+		goTool := filepath.Join(build.Default.GOROOT, "bin", "go")
+		if runtime.GOOS == "windows" {
+			goTool += ".exe"
+		}
+
+		cmd := exec.Command(goTool, "list", "std")
+		cmd.Dir = build.Default.GOROOT
+		out, err := cmd.Output()
+		if err != nil {
+			return
+		}
+		stdlib.index = parseStdlib(out)
+	})
+	return stdlib.index
+}
+
+// parseStdlib parses output of go list std, internal and vendored packages are skipped
+func parseStdlib(out []byte) *stdlibIndex {
+	idx := &stdlibIndex{names: make(map[string]string), paths: make(map[string]bool)}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		importPath := strings.TrimSpace(scanner.Text())
+		if importPath == "" || isInternal(importPath) {
+			continue
+		}
+
+		idx.paths[importPath] = true
+		name := path.Base(trimVersionSuffix(importPath))
+		if _, ok := idx.names[name]; !ok {
+			idx.names[name] = importPath
+		}
+	}
+	return idx
+}
+
+// collision returns import path of standard library package named as package
+func (idx *stdlibIndex) collision(pkgPath, name string) (string, bool) {
+	if idx == nil || idx.paths[pkgPath] {
+		return "", false
+	}
+	importPath, ok := idx.names[name]
+	return importPath, ok
+}
+
+func isInternal(importPath string) bool {
+	for _, elem := range strings.Split(importPath, "/") {
+		if elem == "internal" || elem == "vendor" {
+			return true
+		}
+	}
+	return false
+}
+
+// trimVersionSuffix returns import path without major version suffix, e.g. math/rand for math/rand/v2
+func trimVersionSuffix(importPath string) string {
+	if prefix, _, ok := module.SplitPathVersion(importPath); ok {
+		return prefix
+	}
+	return importPath
+}
@@ -0,0 +1,95 @@
+package goodpackagenames
+
+import (
+	"go/ast"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkStutter reports exported types, functions and constants, which names start with package name
+func checkStutter(pass *analysis.Pass, file *ast.File) {
+	pkgName := pass.Pkg.Name()
+	if pkgName == "main" || strings.HasSuffix(pkgName, packageTestSuffix) {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				checkStutterName(pass, pkgName, decl.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					checkStutterName(pass, pkgName, spec.Name)
+				case *ast.ValueSpec:
+					if decl.Tok != token.CONST {
+						continue
+					}
+					for _, name := range spec.Names {
+						checkStutterName(pass, pkgName, name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func checkStutterName(pass *analysis.Pass, pkgName string, ident *ast.Ident) {
+	if !ident.IsExported() || stutterExempt(pkgName, ident.Name) {
+		return
+	}
+
+	trimmed, ok := trimStutter(pkgName, ident.Name)
+	if !ok {
+		return
+	}
+
+	// no fix is suggested: renaming exported name breaks its importers
+	if pass.Pkg.Scope().Lookup(trimmed) != nil {
+		pass.Reportf(ident.Pos(), "%s.%s stutters", pkgName, ident.Name)
+		return
+	}
+	pass.Reportf(ident.Pos(), "%s.%s stutters, use %s.%s", pkgName, ident.Name, pkgName, trimmed)
+}
+
+// stutterExempt reports whether name matches one of exemption patterns, e.g. errors.Err*
+func stutterExempt(pkgName, name string) bool {
+	return slices.ContainsFunc(flagStutterExempt, func(pattern string) bool {
+		matched, _ := path.Match(pattern, pkgName+"."+name)
+		return matched
+	})
+}
+
+// trimStutter returns name without package name prefix. Prefix must be followed by a new word,
+// so initialisms are kept whole: HTTPServer is trimmed to Server, but HTTPSProxy is kept as is.
+func trimStutter(pkgName, name string) (string, bool) {
+	if len(name) <= len(pkgName) || !strings.EqualFold(name[:len(pkgName)], pkgName) {
+		return "", false
+	}
+
+	rest := name[len(pkgName):]
+	first, size := utf8.DecodeRuneInString(rest)
+	if !unicode.IsUpper(first) {
+		return "", false
+	}
+
+	// upper case letter after initialism starts new word only if it's followed by lower case one
+	last, _ := utf8.DecodeLastRuneInString(name[:len(pkgName)])
+	if unicode.IsUpper(last) {
+		next, _ := utf8.DecodeRuneInString(rest[size:])
+		if !unicode.IsLower(next) {
+			return "", false
+		}
+	}
+
+	return rest, true
+}
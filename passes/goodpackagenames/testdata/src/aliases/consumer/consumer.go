@@ -0,0 +1,24 @@
+package consumer
+
+import (
+	crand "crypto/rand"
+	stderrors "errors"
+	"math/rand"
+	str "strings" // want `unnecessary import alias str for package strings, its name strings doesn't collide with other names`
+
+	cache "aliases/go-cache" // want `redundant import alias cache, package is already named cache`
+	redis "aliases/go-redis/v2" // want `redundant import alias redis, package is already named redis`
+	model "aliases/named"
+)
+
+const errors = "errors"
+
+var (
+	_ = crand.Reader
+	_ = rand.Int
+	_ = stderrors.New
+	_ = str.ToUpper
+	_ = cache.Name
+	_ = redis.Name
+	_ = model.Name
+)
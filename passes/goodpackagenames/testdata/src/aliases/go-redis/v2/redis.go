@@ -0,0 +1,3 @@
+package redis
+
+const Name = "redis"
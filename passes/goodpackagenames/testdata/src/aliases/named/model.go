@@ -0,0 +1,3 @@
+package model // want `package name model doesn't match import path aliases/named, use named`
+
+const Name = "model"
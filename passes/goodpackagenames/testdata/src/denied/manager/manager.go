@@ -0,0 +1,3 @@
+package manager // want `meaningless package name manager, use name describing what package provides`
+
+const Name = "manager"
@@ -0,0 +1,3 @@
+package errors // want `package name errors collides with standard library package errors`
+
+const Code = 1
@@ -0,0 +1,3 @@
+package errors_test // want `package name errors collides with standard library package errors`
+
+const Case = 1
@@ -0,0 +1,3 @@
+package manager
+
+const Name = "manager"
@@ -0,0 +1,3 @@
+package sort // want `package name sort collides with standard library package sort`
+
+const Order = 1
@@ -0,0 +1,3 @@
+package util // want `meaningless package name util, use name describing what package provides`
+
+const Version = 1
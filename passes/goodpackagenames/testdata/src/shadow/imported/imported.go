@@ -0,0 +1,40 @@
+package imported
+
+import (
+	"errors"
+	"net/url"
+	pathpkg "path"
+	"strings"
+)
+
+func Parse(raw string) (url *url.URL, err error) { // want `url shadows imported package net/url`
+	return nil, errors.New(raw)
+}
+
+func Join(path string, parts ...string) string {
+	strings := append([]string{path}, parts...) // want `strings shadows imported package strings`
+	return pathpkg.Join(strings...)
+}
+
+func Wrap(errors []error) error { // want `errors shadows imported package errors`
+	return nil
+}
+
+func Closure() {
+	_ = func(pathpkg string) {} // want `pathpkg shadows imported package path`
+	const url = "x"             // want `url shadows imported package net/url`
+	type strings struct{}       // want `strings shadows imported package strings`
+}
+
+func NoShadowing(u *url.URL, path, time string) string {
+	return strings.TrimSpace(u.Path + path + time)
+}
+
+type Options struct {
+	url     string
+	strings []string
+}
+
+func (o Options) URL() string {
+	return o.url
+}
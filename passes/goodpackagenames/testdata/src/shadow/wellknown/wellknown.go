@@ -0,0 +1,18 @@
+package wellknown
+
+import "fmt"
+
+func Format(time int, fmt string) string { // want `time shadows name of standard library package time` `fmt shadows imported package fmt`
+	return ""
+}
+
+func Print(v any) {
+	fmt.Println(v)
+	for _, path := range []string{"a"} { // want `path shadows name of standard library package path`
+		_ = path
+	}
+}
+
+func Name(user string) string {
+	return user
+}
@@ -0,0 +1,9 @@
+package api
+
+type APIClient struct{} // want `api.APIClient stutters, use api.Client`
+
+type APIs []string
+
+const APIVersion = "v1"
+
+func APIV2() {}
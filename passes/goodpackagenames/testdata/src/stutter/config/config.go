@@ -0,0 +1,37 @@
+package config
+
+type Config struct {
+	Path string
+}
+
+type ConfigLoader struct { // want `config.ConfigLoader stutters, use config.Loader`
+	config Config
+}
+
+func NewConfigLoader() *ConfigLoader {
+	return &ConfigLoader{}
+}
+
+func ConfigFromEnv() Config { // want `config.ConfigFromEnv stutters, use config.FromEnv`
+	return Config{}
+}
+
+const ConfigVersion = 2 // want `config.ConfigVersion stutters, use config.Version`
+
+const Configured = true
+
+var ConfigPath = "config.yaml"
+
+type configState int
+
+func (l *ConfigLoader) ConfigPath() string {
+	return l.config.Path
+}
+
+type Reader interface{}
+
+type ConfigReader interface{} // want `config.ConfigReader stutters$`
+
+func Load() Config {
+	return ConfigFromEnv()
+}
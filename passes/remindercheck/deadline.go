@@ -0,0 +1,135 @@
+package remindercheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	defaultDateFormat    = "2006-01-02"
+	defaultVersionFormat = `^v\d+(\.\d+){0,2}$`
+)
+
+// cutDue cuts due date or version following keyword, e.g. TODO(2026-12-01) or FIXME(v3.0).
+// Text is returned in form of reminder without due, so it can be parsed as usual.
+func (p *parser) cutDue(keyword, text string) (string, reminder) {
+	rest := text[len(keyword):]
+	if !strings.HasPrefix(rest, "(") {
+		return text, reminder{}
+	}
+
+	due, rest, ok := strings.Cut(rest[1:], ")")
+	if !ok {
+		return text, reminder{}
+	}
+
+	var r reminder
+	if date, err := time.ParseInLocation(p.dateFormat, due, time.Local); err == nil {
+		r = reminder{due: due, dueDate: date}
+	} else if p.versionRe.MatchString(due) && semver.IsValid(due) {
+		r = reminder{due: due, dueVersion: due}
+	} else {
+		return text, reminder{}
+	}
+
+	rest = strings.TrimPrefix(rest, ":")
+	rest = strings.TrimPrefix(rest, " ")
+	return keyword + ": " + rest, r
+}
+
+// expiryChecker reports reminders, which due date or version has been reached
+type expiryChecker struct {
+	now time.Time
+	// version is a current module version, empty if unknown
+	version string
+}
+
+func newExpiryChecker(pass *analysis.Pass, dateFormat string) (*expiryChecker, error) {
+	ec := &expiryChecker{now: time.Now()}
+
+	if now := pass.Analyzer.Flags.Lookup("now").Value.String(); now != "" {
+		// parse in the same location as time.Now and due dates
+		t, err := time.ParseInLocation(dateFormat, now, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("parse now flag: %w", err)
+		}
+		ec.now = t
+	}
+
+	ec.version = pass.Analyzer.Flags.Lookup("current-version").Value.String()
+	if ec.version == "" {
+		ec.version = moduleVersion(pass)
+	}
+	if ec.version != "" && !semver.IsValid(ec.version) {
+		return nil, fmt.Errorf("invalid current version %q", ec.version)
+	}
+
+	return ec, nil
+}
+
+func (ec *expiryChecker) check(r reminder) error {
+	switch {
+	case !r.dueDate.IsZero():
+		// reminder is expired after its due day
+		if !ec.now.Before(r.dueDate.AddDate(0, 0, 1)) {
+			return fmt.Errorf("%s has expired: due date %s has passed", r.keyword, r.due)
+		}
+	case r.dueVersion != "" && ec.version != "":
+		if semver.Compare(ec.version, r.dueVersion) >= 0 {
+			return fmt.Errorf("%s has expired: module version %s has reached %s", r.keyword, ec.version, r.dueVersion)
+		}
+	}
+
+	return nil
+}
+
+// moduleVersion returns version of analyzed module. Version of main module isn't known,
+// so only its major version is taken from module path, e.g. v3.0.0 for example.com/mod/v3.
+// Module path without major version suffix belongs to either v0 or v1 module,
+// so empty version is returned and reminders due by version aren't checked.
+func moduleVersion(pass *analysis.Pass) string {
+	if pass.Module != nil && pass.Module.Version != "" {
+		return pass.Module.Version
+	}
+
+	var modulePath string
+	if pass.Module != nil {
+		modulePath = pass.Module.Path
+	}
+	// module path isn't provided by some drivers, e.g. go vet
+	if modulePath == "" && len(pass.Files) > 0 {
+		modulePath = findModulePath(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+	}
+
+	_, major, ok := module.SplitPathVersion(modulePath)
+	if !ok || major == "" {
+		return ""
+	}
+
+	// major version suffix is /vN or .vN for gopkg.in
+	return semver.Major(major[1:]) + ".0.0"
+}
+
+// findModulePath returns module path from go.mod in dir or its parents
+func findModulePath(dir string) string {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return modfile.ModulePath(data)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
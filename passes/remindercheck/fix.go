@@ -0,0 +1,85 @@
+package remindercheck
+
+import (
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lenientBody matches reminder body with recoverable task id and summary,
+// e.g. " PROJ-1 text", ":  (PROJ-1)  text" or "(PROJ-1): text"
+var lenientBody = regexp.MustCompile(`^\s*:?\s*\(?\s*([a-zA-Z][a-zA-Z\-]*-(\d+))\s*\)?\s*:?\s*(.*)$`)
+
+// suggestFixes returns fix, which rewrites reminder line to canonical form
+// '// KEYWORD: TASKID-N: summary', if task id and summary can be recovered from it.
+// Reminders failed task tracker or due checks are well-formed and aren't fixed.
+func (p *parser) suggestFixes(line commentLine, r reminder, continuation string) []analysis.SuggestedFix {
+	if r.taskID != "" {
+		return nil
+	}
+
+	text := strings.TrimRight(cutTrailingComment(line.text), " \t")
+	canonical, ok := p.canonical(text, continuation)
+	if !ok || canonical == text {
+		return nil
+	}
+
+	pos, end := line.textPos, line.textPos+token.Pos(len(text))
+	newText := canonical
+	if strings.HasPrefix(line.comment.Text, "//") {
+		// line comments get exactly one space after slashes
+		pos, newText = line.comment.Pos()+2, " "+canonical
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Rewrite as '// " + canonical + "'",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     end,
+			NewText: []byte(newText),
+		}},
+	}}
+}
+
+// canonical returns reminder text in canonical form
+func (p *parser) canonical(text, continuation string) (string, bool) {
+	_, to := p.findKeyword(text)
+	if to == 0 {
+		return "", false
+	}
+
+	keyword := strings.ToUpper(text[:to])
+	rest, r := p.cutDue(text[:to], text)
+
+	due := ""
+	if r.due != "" {
+		due = "(" + r.due + ")"
+	}
+
+	match := lenientBody.FindStringSubmatch(rest[to:])
+	if match == nil {
+		return "", false
+	}
+
+	taskID, summary := match[1], strings.Join(strings.Fields(match[3]), " ")
+	if n, err := strconv.Atoi(match[2]); err != nil || n < 1 {
+		return "", false
+	}
+	if summary == "" && continuation == "" {
+		return "", false
+	}
+
+	canonical := keyword + due + ": " + taskID + ":"
+	if summary != "" {
+		canonical += " " + summary
+	}
+
+	// fixed reminder must pass configured format
+	if _, err := p.checkComment(canonical, continuation); err != nil {
+		return "", false
+	}
+	return canonical, true
+}
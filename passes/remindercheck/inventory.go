@@ -0,0 +1,229 @@
+package remindercheck
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Inventory is a list of reminders found in package. It is a result of analyzer
+// and a content of JSON file written with -export flag.
+type Inventory struct {
+	Package   string     `json:"package"`
+	Reminders []Reminder `json:"reminders"`
+}
+
+// Reminder is a TODO/FIXME/BUG comment
+type Reminder struct {
+	Keyword string `json:"keyword"`
+	// TaskID is empty for reminders without valid task id
+	TaskID  string `json:"task_id,omitempty"`
+	Queue   string `json:"queue,omitempty"`
+	Summary string `json:"summary,omitempty"`
+	// Due is a due date or version of reminder
+	Due  string `json:"due,omitempty"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Func is a name of enclosing function or method, e.g. (*T).Method
+	Func string `json:"func,omitempty"`
+	Text string `json:"text"`
+}
+
+func (inv *Inventory) add(pass *analysis.Pass, line commentLine, r reminder, fn string) {
+	position := pass.Fset.Position(line.pos)
+	queue, _, _ := strings.Cut(r.taskID, "-")
+
+	inv.Reminders = append(inv.Reminders, Reminder{
+		Keyword: r.keyword,
+		TaskID:  r.taskID,
+		Queue:   queue,
+		Summary: r.summary,
+		Due:     r.due,
+		File:    position.Filename,
+		Line:    position.Line,
+		Func:    fn,
+		Text:    line.text,
+	})
+}
+
+// write writes inventory to JSON file in dir. File is named after package path and hash of package files,
+// so variants of the same package (p, p [p.test] and p_test) don't overwrite each other.
+func (inv *Inventory) write(dir string, files []string) error {
+	data, err := json.MarshalIndent(inv, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create export dir: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join(slices.Sorted(slices.Values(files)), "\n")))
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(inv.Package) + "." + hex.EncodeToString(hash[:6]) + ".json"
+	return os.WriteFile(filepath.Join(dir, name), data, 0o644)
+}
+
+// Report is a repository-wide inventory of reminders grouped by queue and task id
+type Report struct {
+	Queues []QueueReport `json:"queues"`
+	// Untracked holds reminders without valid task id
+	Untracked []Reminder `json:"untracked,omitempty"`
+}
+
+type QueueReport struct {
+	Queue string       `json:"queue"`
+	Tasks []TaskReport `json:"tasks"`
+}
+
+type TaskReport struct {
+	TaskID    string     `json:"task_id"`
+	Reminders []Reminder `json:"reminders"`
+}
+
+// MergeInventories merges per-package inventories written with -export flag to dir into one report
+func MergeInventories(dir string) (*Report, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var inventories []*Inventory
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		inv := new(Inventory)
+		if err := json.Unmarshal(data, inv); err != nil {
+			return nil, fmt.Errorf("parse inventory %s: %w", file, err)
+		}
+		inventories = append(inventories, inv)
+	}
+
+	return NewReport(inventories...), nil
+}
+
+// NewReport groups reminders of inventories by queue and task id.
+// Reminders found in several variants of the same package are reported once.
+func NewReport(inventories ...*Inventory) *Report {
+	report := &Report{Queues: []QueueReport{}}
+	tasks := make(map[string][]Reminder)
+
+	type position struct {
+		file string
+		line int
+	}
+	seen := make(map[position]bool)
+
+	for _, inv := range inventories {
+		for _, r := range inv.Reminders {
+			pos := position{r.File, r.Line}
+			if seen[pos] {
+				continue
+			}
+			seen[pos] = true
+
+			if r.TaskID == "" {
+				report.Untracked = append(report.Untracked, r)
+				continue
+			}
+			taskID := strings.ToUpper(r.TaskID)
+			tasks[taskID] = append(tasks[taskID], r)
+		}
+	}
+
+	queues := make(map[string]*QueueReport)
+	for taskID, reminders := range tasks {
+		queue, _, _ := strings.Cut(taskID, "-")
+		if queues[queue] == nil {
+			queues[queue] = &QueueReport{Queue: queue}
+		}
+
+		slices.SortFunc(reminders, compareReminders)
+		queues[queue].Tasks = append(queues[queue].Tasks, TaskReport{TaskID: taskID, Reminders: reminders})
+	}
+
+	for _, q := range queues {
+		slices.SortFunc(q.Tasks, func(a, b TaskReport) int {
+			return cmp.Compare(a.TaskID, b.TaskID)
+		})
+		report.Queues = append(report.Queues, *q)
+	}
+	slices.SortFunc(report.Queues, func(a, b QueueReport) int {
+		return cmp.Compare(a.Queue, b.Queue)
+	})
+	slices.SortFunc(report.Untracked, compareReminders)
+
+	return report
+}
+
+func compareReminders(a, b Reminder) int {
+	return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+}
+
+// funcIndex finds functions enclosing comments
+type funcIndex []*ast.FuncDecl
+
+func newFuncIndex(file *ast.File) funcIndex {
+	var funcs funcIndex
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			funcs = append(funcs, fn)
+		}
+	}
+	return funcs
+}
+
+// enclosing returns name of function, which contains position or documented by comment at position
+func (idx funcIndex) enclosing(pos token.Pos) string {
+	for _, fn := range idx {
+		from := fn.Pos()
+		if fn.Doc != nil {
+			from = fn.Doc.Pos()
+		}
+		if from <= pos && pos < fn.End() {
+			return funcName(fn)
+		}
+	}
+	return ""
+}
+
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	recv, ptr := fn.Recv.List[0].Type, false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv, ptr = star.X, true
+	}
+
+	// drop type parameters of generic receiver
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		recv = t.X
+	case *ast.IndexListExpr:
+		recv = t.X
+	}
+
+	ident, ok := recv.(*ast.Ident)
+	switch {
+	case !ok:
+		return fn.Name.Name
+	case ptr:
+		return "(*" + ident.Name + ")." + fn.Name.Name
+	default:
+		return ident.Name + "." + fn.Name.Name
+	}
+}
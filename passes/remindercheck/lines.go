@@ -0,0 +1,74 @@
+package remindercheck
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// commentLine is a single line of comment group without comment markers
+type commentLine struct {
+	// pos is a position of comment for line comments and of line content for block comments
+	pos token.Pos
+	// text is a content of line after // or inside /* */ with leading blanks and * removed
+	text string
+	// textPos is a position of the first character of text
+	textPos token.Pos
+	comment *ast.Comment
+}
+
+// commentLines splits comment group into lines of both line and block comments
+func commentLines(cg *ast.CommentGroup) []commentLine {
+	var lines []commentLine
+	for _, c := range cg.List {
+		if strings.HasPrefix(c.Text, "//") {
+			text, offset := trimBlank(c.Text[2:], 2)
+			lines = append(lines, commentLine{pos: c.Pos(), text: text, textPos: c.Pos() + token.Pos(offset), comment: c})
+			continue
+		}
+
+		// block comment: split /* ... */ content into lines
+		body := strings.TrimSuffix(c.Text[2:], "*/")
+		offset := 2
+		for _, raw := range strings.SplitAfter(body, "\n") {
+			text, shift := trimBlank(strings.TrimRight(raw, "\r\n"), offset)
+			// decoration of block comment lines, e.g. " * TODO: ..."
+			if strings.HasPrefix(text, "*") {
+				text, shift = trimBlank(text[1:], shift+1)
+			}
+
+			pos := c.Pos() + token.Pos(shift)
+			lines = append(lines, commentLine{pos: pos, text: text, textPos: pos, comment: c})
+			offset += len(raw)
+		}
+	}
+	return lines
+}
+
+// trimBlank trims leading blanks of text, which starts at given offset, and returns offset of the rest
+func trimBlank(text string, offset int) (string, int) {
+	trimmed := strings.TrimLeft(text, " \t")
+	return trimmed, offset + len(text) - len(trimmed)
+}
+
+// continuation returns text of lines continuing reminder body:
+// following non-empty lines, which don't start another reminder
+func (p *parser) continuation(lines []commentLine) string {
+	var parts []string
+	for _, line := range lines {
+		text := strings.TrimSpace(cutTrailingComment(line.text))
+		if text == "" || p.hasKeyword(line.text) {
+			break
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// cutTrailingComment cuts // comment at the end of line
+func cutTrailingComment(text string) string {
+	if idx := strings.Index(text, "//"); idx != -1 {
+		return text[:idx]
+	}
+	return text
+}
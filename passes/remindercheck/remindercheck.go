@@ -2,9 +2,11 @@ package remindercheck
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"golang.org/x/tools/go/analysis"
@@ -15,10 +17,20 @@ func Analyzer() *analysis.Analyzer {
 		Name: "remindercheck",
 		Doc:  "Checks remind comments are formatted properly",
 		Run:  run,
+
+		ResultType: reflect.TypeFor[*Inventory](),
 	}
 
 	a.Flags.String("keywords", defaultKeywords, "Comment patterns to check")
 	a.Flags.String("format", defaultFormat, "Regular expression for get content groups")
+	a.Flags.String("tasks-file", "", "Path to JSON or CSV export of known tasks with their statuses")
+	a.Flags.String("closed-statuses", defaultClosedStatuses, "Comma-separated statuses of closed tasks")
+	a.Flags.String("queues", "", "Comma-separated list of allowed task queues, all queues are allowed if empty")
+	a.Flags.String("date-format", defaultDateFormat, "Layout of reminder due date, e.g. TODO(2026-12-01)")
+	a.Flags.String("version-format", defaultVersionFormat, "Regular expression of reminder due version, e.g. TODO(v3.0)")
+	a.Flags.String("now", "", "Current date in date-format used to find expired reminders, today if empty")
+	a.Flags.String("current-version", "", "Current module version used to find expired reminders, major version is read from go.mod if empty")
+	a.Flags.String("export", "", "Directory to write JSON inventory of reminders of each package to")
 
 	return a
 }
@@ -43,66 +55,135 @@ func run(pass *analysis.Pass) (any, error) {
 		keywords[i] = strings.ToLower(strings.TrimSpace(keyword))
 	}
 
+	versionRe, err := regexp.Compile(pass.Analyzer.Flags.Lookup("version-format").Value.String())
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{
+		keywords:   keywords,
+		re:         re,
+		dateFormat: pass.Analyzer.Flags.Lookup("date-format").Value.String(),
+		versionRe:  versionRe,
+	}
+
+	tc, err := newTaskChecker(&pass.Analyzer.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := newExpiryChecker(pass, p.dateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{Package: pass.Pkg.Path()}
+
 	for _, file := range pass.Files {
+		funcs := newFuncIndex(file)
 		for _, cg := range file.Comments {
-			for _, c := range cg.List {
-				err := checkComment(c.Text, keywords, re)
+			lines := commentLines(cg)
+			for i, line := range lines {
+				if !p.hasKeyword(line.text) {
+					continue
+				}
+
+				continuation := p.continuation(lines[i+1:])
+				r, err := p.checkComment(line.text, continuation)
+				inventory.add(pass, line, r, funcs.enclosing(line.pos))
+				if err == nil && r.taskID != "" {
+					err = tc.check(r)
+				}
+				if err == nil {
+					err = ec.check(r)
+				}
 				if err != nil {
-					pass.Reportf(c.Pos(), "%s", err.Error())
+					pass.Report(analysis.Diagnostic{
+						Pos:            line.pos,
+						Message:        err.Error(),
+						SuggestedFixes: p.suggestFixes(line, r, continuation),
+					})
 				}
 			}
 		}
 	}
 
-	return nil, nil
+	if dir := pass.Analyzer.Flags.Lookup("export").Value.String(); dir != "" && len(inventory.Reminders) > 0 {
+		files := make([]string, 0, len(pass.Files))
+		for _, file := range pass.Files {
+			files = append(files, pass.Fset.File(file.Pos()).Name())
+		}
+		if err := inventory.write(dir, files); err != nil {
+			return nil, err
+		}
+	}
+
+	return inventory, nil
+}
+
+// reminder is a parsed reminder comment
+type reminder struct {
+	keyword string
+	taskID  string
+	summary string
+	// due is a due date or version of reminder, e.g. 2026-12-01 in TODO(2026-12-01)
+	due string
+	// dueDate is set if reminder is due by date
+	dueDate time.Time
+	// dueVersion is set if reminder is due by module version
+	dueVersion string
+}
+
+// parser parses reminder comments
+type parser struct {
+	keywords   []string
+	re         *regexp.Regexp
+	dateFormat string
+	versionRe  *regexp.Regexp
 }
 
-func checkComment(text string, keywords []string, re *regexp.Regexp) error {
+// checkComment parses reminder at the beginning of comment line.
+// Reminder summary may be placed on continuation lines.
+func (p *parser) checkComment(text, continuation string) (reminder, error) {
 	const (
 		doubleSlashes = "//"
 	)
 
-	if text[0:2] != doubleSlashes {
-		return nil
-	}
-
-	if len(text) < 3 {
-		return nil
-	}
-
-	text = text[3:]
-	from, to := findKeyword(text, keywords)
+	from, to := p.findKeyword(text)
 	if to == 0 || from != 0 {
-		return nil
+		return reminder{}, nil
 	}
 
 	keyword := text[from:to]
 	if !isAllUpperCase(keyword) {
 		hint := fmt.Sprintf(hintTemplate, strings.ToUpper(keyword), taskIDHint)
 
-		return fmt.Errorf("keyword '%s' must be upper case. Required template: %s", keyword, hint)
+		return reminder{keyword: keyword}, fmt.Errorf("keyword '%s' must be upper case. Required template: %s", keyword, hint)
 	}
 
 	if idx := strings.Index(text, doubleSlashes); idx != -1 {
 		text = text[:idx]
 	}
 
+	var r reminder
+	text, r = p.cutDue(keyword, text)
+
 	shift := len(keyword) + 2
-	if len(text) <= shift {
-		return makeReportWithRightParts(keyword)
+	if len(text) <= shift || text[len(keyword)] != ':' || !unicode.IsSpace(rune(text[len(keyword)+1])) {
+		return reminder{keyword: keyword}, makeReportWithRightParts(keyword)
 	}
 
-	match := re.FindStringSubmatch(text[shift:])
+	match := p.re.FindStringSubmatch(text[shift:])
 	const countStringsMatchParts = 3
 	if len(match) < countStringsMatchParts {
-		return makeReportWithRightParts(keyword)
+		return reminder{keyword: keyword}, makeReportWithRightParts(keyword)
 	}
 
 	taskID, summary := match[1], match[2]
 	if taskID == "" {
 		hint := fmt.Sprintf(hintTemplate, strings.ToUpper(keyword), taskIDHint)
 
-		return fmt.Errorf("%s must include task id. Required template: %s", keyword, hint)
+		return reminder{keyword: keyword}, fmt.Errorf("%s must include task id. Required template: %s", keyword, hint)
 	}
 
 	taskArr := strings.Split(taskID, "-")
@@ -110,27 +191,25 @@ func checkComment(text string, keywords []string, re *regexp.Regexp) error {
 	if len(taskArr) < taskArrCount {
 		hint := fmt.Sprintf(hintTemplate, strings.ToUpper(keyword), taskIDHint)
 
-		return fmt.Errorf("%s must use valid task id: %s. Required template: %s", keyword, taskID, hint)
+		return reminder{keyword: keyword}, fmt.Errorf("%s must use valid task id: %s. Required template: %s", keyword, taskID, hint)
 	}
 
 	id, err := strconv.Atoi(taskArr[1])
 	if err != nil || id < 1 {
 		hint := fmt.Sprintf(hintTemplate, strings.ToUpper(keyword), taskIDHint)
 
-		return fmt.Errorf("%s must use task id number greater zero: %s. Required template: %s", keyword, taskID, hint)
-	}
-
-	if idx := strings.Index(summary, doubleSlashes); idx != -1 {
-		summary = summary[:id]
+		return reminder{keyword: keyword}, fmt.Errorf("%s must use task id number greater zero: %s. Required template: %s", keyword, taskID, hint)
 	}
 
-	if strings.TrimSpace(summary) == "" {
+	summary = strings.Join(strings.Fields(summary+" "+continuation), " ")
+	if summary == "" {
 		hint := fmt.Sprintf(hintTemplate, strings.ToUpper(keyword), taskID)
 
-		return fmt.Errorf("%s must describe what needs to remind. Required template: %s", keyword, hint)
+		return reminder{keyword: keyword}, fmt.Errorf("%s must describe what needs to remind. Required template: %s", keyword, hint)
 	}
 
-	return nil
+	r.keyword, r.taskID, r.summary = keyword, taskID, summary
+	return r, nil
 }
 
 func makeReportWithRightParts(keyword string) error {
@@ -139,18 +218,32 @@ func makeReportWithRightParts(keyword string) error {
 	return fmt.Errorf("%s must be contains right parts. Required template: %s", keyword, hint)
 }
 
-func findKeyword(str string, words []string) (from, to int) {
-	str = strings.ToLower(str)
+// findKeyword finds keyword at the beginning of text, which is followed by non-word character
+func (p *parser) findKeyword(str string) (from, to int) {
+	lower := strings.ToLower(str)
 
-	for _, w := range words {
-		if i := strings.Index(str, w); i >= 0 {
-			return i, i + len(w)
+	for _, w := range p.keywords {
+		if w == "" || !strings.HasPrefix(lower, w) {
+			continue
 		}
+		if rest := str[len(w):]; rest != "" && isWordChar(rune(rest[0])) {
+			continue
+		}
+		return 0, len(w)
 	}
 
 	return 0, 0
 }
 
+func (p *parser) hasKeyword(text string) bool {
+	_, to := p.findKeyword(text)
+	return to != 0
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
 func isAllUpperCase(s string) bool {
 	for _, r := range s {
 		if unicode.IsLetter(r) && !unicode.IsUpper(r) {
@@ -1,8 +1,13 @@
 package remindercheck_test
 
 import (
+	"path/filepath"
+	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"golang.org/x/tools/go/analysis/analysistest"
 
 	"golang.yandex/linters/passes/remindercheck"
@@ -13,3 +18,125 @@ func TestRun(t *testing.T) {
 
 	analysistest.RunWithSuggestedFixes(t, testdata, remindercheck.Analyzer())
 }
+
+func TestTasksFile(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	for _, file := range []string{"tasks.json", "tasks.csv"} {
+		t.Run(file, func(t *testing.T) {
+			a := remindercheck.Analyzer()
+			require.NoError(t, a.Flags.Set("tasks-file", filepath.Join(testdata, "src", "tasks", file)))
+			require.NoError(t, a.Flags.Set("queues", "PROJ, CORE"))
+
+			analysistest.Run(t, testdata, a, "tasks")
+		})
+	}
+}
+
+func TestDeadlines(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	a := remindercheck.Analyzer()
+	require.NoError(t, a.Flags.Set("now", "2026-06-15"))
+	require.NoError(t, a.Flags.Set("current-version", "v3.1.0"))
+
+	analysistest.Run(t, testdata, a, "deadline")
+}
+
+func TestDeadlinesUnknownVersion(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	a := remindercheck.Analyzer()
+	require.NoError(t, a.Flags.Set("now", "2026-06-15"))
+
+	analysistest.Run(t, testdata, a, "unknownversion")
+}
+
+func TestExport(t *testing.T) {
+	testdata := analysistest.TestData()
+	dir := t.TempDir()
+
+	a := remindercheck.Analyzer()
+	require.NoError(t, a.Flags.Set("export", dir))
+
+	results := analysistest.Run(t, testdata, a, "inventory")
+	require.Len(t, results, 1)
+
+	inventory := results[0].Result.(*remindercheck.Inventory)
+	assert.Equal(t, "inventory", inventory.Package)
+
+	type entry struct {
+		keyword, taskID, summary, due, fn string
+		line                              int
+	}
+	var entries []entry
+	for _, r := range inventory.Reminders {
+		entries = append(entries, entry{r.Keyword, r.TaskID, r.Summary, r.Due, r.Func, r.Line})
+	}
+	assert.Equal(t, []entry{
+		{"TODO", "PROJ-1", "support oat milk", "", "", 3},
+		{"FIXME", "PROJ-2", "use proper grinder", "v2.0", "(*Coffee).Brew", 7},
+		{"TODO", "CORE-7", "heat water first", "", "(*Coffee).Brew", 9},
+		{"BUG", "PROJ-1", "cups are too small", "", "Serve", 13},
+		{"TODO", "", "", "", "Serve", 14},
+	}, entries)
+
+	report, err := remindercheck.MergeInventories(dir)
+	require.NoError(t, err)
+
+	var queues []string
+	for _, q := range report.Queues {
+		for _, task := range q.Tasks {
+			queues = append(queues, q.Queue+"/"+task.TaskID+":"+strconv.Itoa(len(task.Reminders)))
+		}
+	}
+	assert.Equal(t, []string{"CORE/CORE-7:1", "PROJ/PROJ-1:2", "PROJ/PROJ-2:1"}, queues)
+	require.Len(t, report.Untracked, 1)
+	assert.Equal(t, 14, report.Untracked[0].Line)
+}
+
+func TestReportVariants(t *testing.T) {
+	reminder := remindercheck.Reminder{Keyword: "TODO", TaskID: "PROJ-1", File: "coffee.go", Line: 3}
+	testReminder := remindercheck.Reminder{Keyword: "TODO", TaskID: "PROJ-1", File: "coffee_test.go", Line: 5}
+
+	report := remindercheck.NewReport(
+		&remindercheck.Inventory{Package: "coffee", Reminders: []remindercheck.Reminder{reminder}},
+		&remindercheck.Inventory{Package: "coffee", Reminders: []remindercheck.Reminder{reminder, testReminder}},
+	)
+
+	require.Len(t, report.Queues, 1)
+	require.Len(t, report.Queues[0].Tasks, 1)
+	assert.Equal(t, []remindercheck.Reminder{reminder, testReminder}, report.Queues[0].Tasks[0].Reminders)
+}
+
+func TestMultiline(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	results := analysistest.Run(t, testdata, remindercheck.Analyzer(), "multiline")
+	require.Len(t, results, 1)
+
+	var summaries []string
+	for _, r := range results[0].Result.(*remindercheck.Inventory).Reminders {
+		summaries = append(summaries, strconv.Itoa(r.Line)+": "+r.TaskID+": "+r.Summary)
+	}
+	assert.Equal(t, []string{
+		"3: TASKID-1: no space after slashes",
+		"5: TASKID-2: summary on continuation line",
+		"8: : ",
+		"11: TASKID-4: reminders in block comments",
+		"12: TASKID-5: decorated block comment lines",
+		"15: TASKID-6: single-line block comment",
+		"16: : ",
+		"18: : ",
+		"23: TASKID-9: reminders in doc comments are continued on the next lines.",
+	}, summaries)
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	a := remindercheck.Analyzer()
+	require.NoError(t, a.Flags.Set("now", "2026-06-15"))
+
+	analysistest.RunWithSuggestedFixes(t, testdata, a, "fixes")
+}
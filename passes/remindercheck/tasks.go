@@ -0,0 +1,164 @@
+package remindercheck
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultClosedStatuses = "closed,resolved"
+
+	keyColumn    = "key"
+	statusColumn = "status"
+)
+
+// task is an entry of tracker export
+type task struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`
+}
+
+// tasksCache holds loaded tracker exports by path, so each export is read once per process
+var tasksCache sync.Map
+
+type tasksEntry struct {
+	once  sync.Once
+	tasks map[string]task
+	err   error
+}
+
+// loadTasks reads tracker export of JSON array of {"key": "QUEUE-1", "status": "open"} objects
+// or CSV table with "key" and "status" columns
+func loadTasks(path string) (map[string]task, error) {
+	v, _ := tasksCache.LoadOrStore(path, new(tasksEntry))
+	entry := v.(*tasksEntry)
+
+	entry.once.Do(func() {
+		entry.tasks, entry.err = readTasks(path)
+	})
+
+	return entry.tasks, entry.err
+}
+
+func readTasks(path string) (map[string]task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tasks file: %w", err)
+	}
+
+	var list []task
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("parse tasks file %s: %w", path, err)
+		}
+	case ".csv":
+		list, err = parseTasksCSV(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse tasks file %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tasks file %s: expected .json or .csv", path)
+	}
+
+	tasks := make(map[string]task, len(list))
+	for _, t := range list {
+		tasks[strings.ToUpper(t.Key)] = t
+	}
+	return tasks, nil
+}
+
+func parseTasksCSV(data string) ([]task, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	keyIdx, statusIdx := slices.Index(header, keyColumn), slices.Index(header, statusColumn)
+	if keyIdx == -1 || statusIdx == -1 {
+		return nil, errors.New(`header must contain "key" and "status" columns`)
+	}
+
+	tasks := make([]task, 0, len(records)-1)
+	for _, record := range records[1:] {
+		tasks = append(tasks, task{Key: strings.TrimSpace(record[keyIdx]), Status: strings.TrimSpace(record[statusIdx])})
+	}
+	return tasks, nil
+}
+
+// taskChecker validates task IDs of reminders against tracker export and allowed queues
+type taskChecker struct {
+	// tasks is nil if tracker export isn't set
+	tasks          map[string]task
+	closedStatuses []string
+	queues         []string
+}
+
+func newTaskChecker(flags *flag.FlagSet) (*taskChecker, error) {
+	tc := &taskChecker{
+		closedStatuses: splitList(flags.Lookup("closed-statuses").Value.String()),
+		queues:         splitList(flags.Lookup("queues").Value.String()),
+	}
+
+	if path := flags.Lookup("tasks-file").Value.String(); path != "" {
+		tasks, err := loadTasks(path)
+		if err != nil {
+			return nil, err
+		}
+		tc.tasks = tasks
+	}
+
+	return tc, nil
+}
+
+func (tc *taskChecker) check(r reminder) error {
+	queue, _, _ := strings.Cut(r.taskID, "-")
+	if len(tc.queues) > 0 && !slices.ContainsFunc(tc.queues, func(q string) bool {
+		return strings.EqualFold(q, queue)
+	}) {
+		return fmt.Errorf("%s must use task from allowed queues (%s): %s", r.keyword, strings.Join(tc.queues, ", "), r.taskID)
+	}
+
+	if tc.tasks == nil {
+		return nil
+	}
+
+	t, ok := tc.tasks[strings.ToUpper(r.taskID)]
+	if !ok {
+		return fmt.Errorf("%s must reference existing task: %s is unknown", r.keyword, r.taskID)
+	}
+
+	if slices.ContainsFunc(tc.closedStatuses, func(status string) bool {
+		return strings.EqualFold(status, t.Status)
+	}) {
+		return fmt.Errorf("%s must reference open task: %s is %s", r.keyword, r.taskID, strings.ToLower(t.Status))
+	}
+
+	return nil
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
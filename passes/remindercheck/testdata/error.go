@@ -1,8 +1,6 @@
 package testdata
 
-/*
-TODO: multi-line comments are ignored
-*/
+/* TODO: block comments are checked */ // want `TODO must be contains right parts. Required template: '// TODO: TASKID-1: comment'`
 
 // Error
 
@@ -0,0 +1,14 @@
+package deadline
+
+// TODO(2026-12-01) PROJ-42: remove fallback
+// TODO(2026-06-15): PROJ-43: due today
+// FIXME(v3.2) PROJ-44: drop deprecated field
+// BUG(v4.0.0): PROJ-45: fix in next major version
+
+// TODO(2026-06-14) PROJ-46: remove fallback   // want `TODO has expired: due date 2026-06-14 has passed`
+// TODO(2025-01-01): PROJ-47: remove fallback  // want `TODO has expired: due date 2025-01-01 has passed`
+// FIXME(v3.0) PROJ-48: drop deprecated field  // want `FIXME has expired: module version v3.1.0 has reached v3.0`
+// FIXME(v3.1.0) PROJ-49: drop deprecated API  // want `FIXME has expired: module version v3.1.0 has reached v3.1.0`
+// TODO(2026-06-14) remove fallback            // want `TODO must be contains right parts. Required template: '// TODO: TASKID-1: comment'`
+// TODO(someday) PROJ-50: remove fallback      // want `TODO must be contains right parts. Required template: '// TODO: TASKID-1: comment'`
+func brewTea() {}
@@ -0,0 +1,24 @@
+package fixes
+
+// todo: PROJ-1: lower case keyword // want `keyword 'todo' must be upper case`
+
+// TODO PROJ-2 missing colons // want `TODO must be contains right parts`
+
+// TODO:  PROJ-3  extra   spaces // want `TODO must include task id`
+
+//fixme PROJ-4: no space after slashes // want `keyword 'fixme' must be upper case`
+
+// TODO(2026-12-01) (PROJ-5) due date is kept // want `TODO must be contains right parts`
+
+/* bug PROJ-6 block comment */ // want `keyword 'bug' must be upper case`
+
+// todo PROJ-7 // want `keyword 'todo' must be upper case`
+// summary on continuation line
+
+// TODO: fix PROJ-8 later // want `TODO must be contains right parts`
+
+// todo: PROJ-0: zero task number // want `keyword 'todo' must be upper case`
+
+// todo: PROJ-9 // want `keyword 'todo' must be upper case`
+
+func brewTea() {}
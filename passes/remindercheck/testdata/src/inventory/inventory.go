@@ -0,0 +1,15 @@
+package inventory
+
+// TODO: PROJ-1: support oat milk
+type Coffee struct{}
+
+// Brew brews a coffee
+// FIXME(v2.0): PROJ-2: use proper grinder
+func (c *Coffee) Brew() {
+	// TODO: CORE-7: heat water first
+}
+
+func Serve() {
+	// BUG: PROJ-1: cups are too small
+	// TODO: serve with cookies // want `TODO must be contains right parts. Required template: '// TODO: TASKID-1: comment'`
+}
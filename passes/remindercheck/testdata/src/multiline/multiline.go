@@ -0,0 +1,29 @@
+package multiline
+
+//TODO: TASKID-1: no space after slashes
+
+// TODO: TASKID-2:
+// summary on continuation line
+
+// TODO: TASKID-3: // want `TODO must describe what needs to remind. Required template: '// TODO: TASKID-3: comment'`
+
+/*
+TODO: TASKID-4: reminders in block comments
+ * FIXME: TASKID-5: decorated block comment lines
+*/
+
+/* BUG: TASKID-6: single-line block comment */
+/* todo: TASKID-7: lower case */ // want `keyword 'todo' must be upper case. Required template: '// TODO: TASKID-1: comment'`
+
+/* FIXME: TASKID-8: // want `FIXME must describe what needs to remind. Required template: '// FIXME: TASKID-8: comment'`
+ */
+
+// makeTea makes tea.
+//
+// TODO: TASKID-9: reminders in doc comments
+// are continued on the next lines.
+func makeTea() {}
+
+// Bugfix of coffee machine is not a reminder.
+// Todos are not reminders either.
+func makeCoffee() {}
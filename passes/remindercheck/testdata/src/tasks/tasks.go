@@ -0,0 +1,11 @@
+package tasks
+
+// TODO: PROJ-1: remove fallback
+// FIXME: CORE-10: refactor tea brewing
+// TODO: proj-1: lower case task ids are matched too
+
+// TODO: PROJ-2: resolved task    // want `TODO must reference open task: PROJ-2 is resolved`
+// BUG: PROJ-3: closed task       // want `BUG must reference open task: PROJ-3 is closed`
+// TODO: PROJ-404: unknown task   // want `TODO must reference existing task: PROJ-404 is unknown`
+// TODO: LEGACY-5: legacy queue   // want `TODO must use task from allowed queues \(PROJ, CORE\): LEGACY-5`
+func brewTea() {}
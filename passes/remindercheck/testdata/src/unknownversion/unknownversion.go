@@ -0,0 +1,7 @@
+package unknownversion
+
+// Module path of this package has no major version suffix, so its version is unknown.
+
+// FIXME(v0.1) PROJ-51: drop deprecated field
+// FIXME(v1.0.0) PROJ-52: drop deprecated API
+func brewCoffee() {}
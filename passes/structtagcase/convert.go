@@ -0,0 +1,79 @@
+package structtagcase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// splitWords splits name into words by separators and case changes. Runs of upper case
+// letters are kept as single word, so initialisms stay whole: UserID is split into User and ID,
+// HTTPServer into HTTP and Server and URLs into URLs.
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' || r == '-' {
+			flush()
+			continue
+		}
+
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			switch {
+			case unicode.IsLower(prev) || unicode.IsDigit(prev):
+				// userID, address2Line
+				flush()
+			case unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralSuffix(runes[i+1:]):
+				// last upper case letter of initialism starts next word: HTTPServer
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return words
+}
+
+// isPluralSuffix reports whether rest of name after initialism is plural suffix, e.g. s of IDs
+func isPluralSuffix(rest []rune) bool {
+	return rest[0] == 's' && (len(rest) == 1 || !unicode.IsLower(rest[1]))
+}
+
+// convertCasing converts name to casing, it returns name as is for unknown casing
+func convertCasing(name string, casing stringCasing) string {
+	words := splitWords(name)
+
+	switch casing {
+	case casingSnake:
+		return strings.ToLower(strings.Join(words, "_"))
+	case casingScreamingSnake:
+		return strings.ToUpper(strings.Join(words, "_"))
+	case casingKebab:
+		return strings.ToLower(strings.Join(words, "-"))
+	case casingCamel:
+		var sb strings.Builder
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				r := []rune(word)
+				r[0] = unicode.ToUpper(r[0])
+				word = string(r)
+			}
+			sb.WriteString(word)
+		}
+		return sb.String()
+	default:
+		return name
+	}
+}
@@ -0,0 +1,86 @@
+package structtagcase
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// renameDirective allows tag names to differ from field name. It may be followed
+// by comma-separated list of keys, e.g. //structtagcase:rename json,db
+const renameDirective = "//structtagcase:rename"
+
+// checkFieldNames reports tag names, which normalised form differs from field name
+func checkFieldNames(pass *analysis.Pass, node *ast.StructType, cfg *config) {
+	for _, field := range node.Fields.List {
+		// embedded fields, lists of fields sharing tag and unexported fields (including _) are skipped
+		if len(field.Names) != 1 || !ast.IsExported(field.Names[0].Name) {
+			continue
+		}
+		fieldName := field.Names[0].Name
+
+		for _, tagKey := range cfg.checkedKeys() {
+			name := fieldTagName(field, tagKey)
+			if name == "" || name == "-" {
+				continue
+			}
+			if normalizeName(name) == normalizeName(fieldName) || renameAllowed(field, tagKey) {
+				continue
+			}
+
+			pass.Report(analysis.Diagnostic{
+				Pos:            field.End(),
+				Message:        fmt.Sprintf("%s struct tag doesn't match field name %s: %s", tagKey, fieldName, name),
+				SuggestedFixes: suggestRename(field, tagKey, name, convertCasing(fieldName, derivedCasing(name, cfg.expectedCasing(tagKey)))),
+			})
+		}
+	}
+}
+
+// derivedCasing returns casing of name derived from field: configured one, casing of current name or snake case
+func derivedCasing(name string, expected stringCasing) stringCasing {
+	if expected != casingUnknown {
+		return expected
+	}
+	if casing := detectCasing(name); casing != casingUnknown && casing != casingMixed {
+		return casing
+	}
+	// single upper case word, e.g. PORT
+	if strings.ToUpper(name) == name && strings.ToLower(name) != name {
+		return casingScreamingSnake
+	}
+	return casingSnake
+}
+
+// normalizeName returns name without separators and case, e.g. createdat for CreatedAt and created_at
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), ""))
+}
+
+// renameAllowed reports whether field is annotated with rename directive for key
+func renameAllowed(field *ast.Field, key string) bool {
+	for _, cg := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			rest, ok := strings.CutPrefix(c.Text, renameDirective)
+			if !ok || rest != "" && rest[0] != ' ' {
+				continue
+			}
+
+			// directive may be followed by other comment
+			rest, _, _ = strings.Cut(rest, "//")
+
+			var keys tagKeys
+			_ = keys.Set(rest)
+			if len(keys) == 0 || slices.Contains(keys, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package structtagcase
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// suggestRename returns fix replacing name of key in field tag with new name
+func suggestRename(field *ast.Field, key, name, newName string) []analysis.SuggestedFix {
+	if newName == "" || newName == name {
+		return nil
+	}
+
+	offset, ok := tagNameOffset(field.Tag.Value, key, name)
+	if !ok {
+		return nil
+	}
+
+	pos := field.Tag.Pos() + token.Pos(offset)
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Rename %s struct tag to %s", key, newName),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     pos,
+			End:     pos + token.Pos(len(name)),
+			NewText: []byte(newName),
+		}},
+	}}
+}
+
+// tagNameOffset returns offset of name of key in tag literal, which is either raw or interpreted string
+func tagNameOffset(lit, key, name string) (int, bool) {
+	quote := `"`
+	if strings.HasPrefix(lit, `"`) {
+		quote = `\"`
+	}
+	prefix := key + ":" + quote
+
+	for from := 0; ; {
+		idx := strings.Index(lit[from:], prefix)
+		if idx == -1 {
+			return 0, false
+		}
+		idx += from
+		from = idx + 1
+
+		// key must start tag or follow space, e.g. not "xjson:" for "json:"
+		if idx != 1 && lit[idx-1] != ' ' {
+			continue
+		}
+
+		start := idx + len(prefix)
+		if key == "protobuf" {
+			end := strings.Index(lit[start:], quote)
+			if end == -1 {
+				return 0, false
+			}
+			opt := strings.Index(lit[start:start+end], ",name="+name)
+			if opt == -1 {
+				return 0, false
+			}
+			start += opt + len(",name=")
+		}
+
+		if !strings.HasPrefix(lit[start:], name) {
+			return 0, false
+		}
+		return start, true
+	}
+}
@@ -0,0 +1,130 @@
+package structtagcase
+
+import (
+	"cmp"
+	"go/ast"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// CasingFact holds dominant casing of struct tag keys in package
+type CasingFact struct {
+	// Casings maps struct tag key to casing
+	Casings map[string]string
+}
+
+func (*CasingFact) AFact() {}
+
+func (f *CasingFact) String() string {
+	items := make([]string, 0, len(f.Casings))
+	for _, key := range slices.Sorted(maps.Keys(f.Casings)) {
+		items = append(items, key+"="+f.Casings[key])
+	}
+	return "casing(" + strings.Join(items, ",") + ")"
+}
+
+// packageCasing is a casing required in struct tag key across package
+type packageCasing struct {
+	casing stringCasing
+	// pkg is a path of related package, which casing is inherited, empty for own casing of package
+	pkg string
+}
+
+// reason returns explanation of required casing for diagnostic
+func (pc packageCasing) reason() string {
+	if pc.pkg == "" {
+		return " used in package"
+	}
+	return " used in package " + pc.pkg
+}
+
+// packageCasings returns casings required across package: inherited from related packages or
+// majority casing of package. Resulting casings are exported as a fact of package.
+func (cfg *config) packageCasings(pass *analysis.Pass, structs []*ast.StructType) map[string]packageCasing {
+	casings := make(map[string]packageCasing)
+
+	inherited := relatedFacts(pass)
+	for _, key := range cfg.checkedKeys() {
+		for _, fact := range inherited {
+			if casing, ok := fact.Fact.(*CasingFact).Casings[key]; ok {
+				casings[key] = packageCasing{casing: stringCasing(casing), pkg: fact.Package.Path()}
+				break
+			}
+		}
+
+		if _, ok := casings[key]; !ok {
+			if casing := majorityCasing(structs, key); casing != casingUnknown {
+				casings[key] = packageCasing{casing: casing}
+			}
+		}
+	}
+
+	if len(casings) > 0 {
+		fact := &CasingFact{Casings: make(map[string]string, len(casings))}
+		for key, pc := range casings {
+			fact.Casings[key] = string(pc.casing)
+		}
+		pass.ExportPackageFact(fact)
+	}
+
+	return casings
+}
+
+// relatedFacts returns casing facts of dependencies, which are parent or child packages
+// of analyzed one, e.g. api/v1/types for api/v1, sorted by package path
+func relatedFacts(pass *analysis.Pass) []analysis.PackageFact {
+	path := pass.Pkg.Path()
+
+	var facts []analysis.PackageFact
+	for _, fact := range pass.AllPackageFacts() {
+		if _, ok := fact.Fact.(*CasingFact); !ok {
+			continue
+		}
+
+		other := fact.Package.Path()
+		if strings.HasPrefix(other, path+"/") || strings.HasPrefix(path, other+"/") {
+			facts = append(facts, fact)
+		}
+	}
+
+	slices.SortFunc(facts, func(a, b analysis.PackageFact) int {
+		return cmp.Compare(a.Package.Path(), b.Package.Path())
+	})
+	return facts
+}
+
+// majorityCasing returns casing used by most names of key in structs,
+// the first detected casing wins a tie
+func majorityCasing(structs []*ast.StructType, key string) stringCasing {
+	counts := make(map[stringCasing]int)
+	var order []stringCasing
+
+	for _, node := range structs {
+		for _, field := range node.Fields.List {
+			name := fieldTagName(field, key)
+			if name == "" || name == "-" {
+				continue
+			}
+
+			casing := detectCasing(name)
+			if casing == casingUnknown || casing == casingMixed {
+				continue
+			}
+			if counts[casing] == 0 {
+				order = append(order, casing)
+			}
+			counts[casing]++
+		}
+	}
+
+	majority := casingUnknown
+	for _, casing := range order {
+		if majority == casingUnknown || counts[casing] > counts[majority] {
+			majority = casing
+		}
+	}
+	return majority
+}
@@ -0,0 +1,188 @@
+package structtagcase
+
+import (
+	"go/ast"
+	"go/types"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// TagAnalyzer checks struct tags for mistakes, which are silently ignored by encoders.
+// Malformed tags and duplicate json and xml names are reported by structtag pass of go vet,
+// so they are not checked here.
+var TagAnalyzer = &analysis.Analyzer{
+	Name: "structtagcheck",
+	Doc: `structtagcheck checks struct tags for unknown or useless options
+and fields, including promoted ones, serialised to the same yaml or db name`,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+	},
+	Run: structtag,
+}
+
+// uniqueKeys are struct tag keys, which names must be unique in struct.
+// Uniqueness of json and xml names is checked by go vet.
+var uniqueKeys = []string{"yaml", "db"}
+
+// knownOptions are options supported by encoders of struct tag keys
+var knownOptions = map[string][]string{
+	"json":         {"omitempty", "omitzero", "string"},
+	"yaml":         {"omitempty", "flow", "inline"},
+	"xml":          {"attr", "chardata", "cdata", "innerxml", "comment", "any", "omitempty"},
+	"bson":         {"omitempty", "minsize", "truncate", "inline"},
+	"mapstructure": {"omitempty", "omitzero", "squash", "remain"},
+	"toml":         {"omitempty", "omitzero", "multiline", "inline", "commented"},
+}
+
+func structtag(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+	}
+
+	ins.Preorder(nodeFilter, func(n ast.Node) {
+		node := n.(*ast.StructType)
+		st, ok := pass.TypesInfo.TypeOf(node).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		for i := range st.NumFields() {
+			checkTagOptions(pass, st.Field(i), st.Tag(i))
+		}
+
+		for _, key := range uniqueKeys {
+			checkDuplicateNames(pass, st, key)
+		}
+	})
+
+	return nil, nil
+}
+
+func checkTagOptions(pass *analysis.Pass, field *types.Var, tag string) {
+	for _, key := range slices.Sorted(maps.Keys(knownOptions)) {
+		value, ok := reflect.StructTag(tag).Lookup(key)
+		if !ok || value == "-" {
+			continue
+		}
+
+		_, opts, _ := strings.Cut(value, ",")
+		if opts == "" {
+			continue
+		}
+
+		for _, opt := range strings.Split(opts, ",") {
+			switch {
+			case opt == "":
+			case !slices.Contains(knownOptions[key], opt):
+				pass.Reportf(field.Pos(), "unknown option %q in %s struct tag of field %s", opt, key, field.Name())
+			case key == "json" && opt == "string" && !isScalar(field.Type()):
+				pass.Reportf(field.Pos(), "json option \"string\" has no effect on field %s of non-scalar type %s",
+					field.Name(), typeString(pass, field.Type()))
+			case key == "json" && opt == "omitempty" && isStruct(field.Type()):
+				pass.Reportf(field.Pos(), "json option \"omitempty\" has no effect on field %s of struct type %s, use pointer instead",
+					field.Name(), typeString(pass, field.Type()))
+			}
+		}
+	}
+}
+
+// isScalar reports whether type is string, number or boolean or pointer to them
+func isScalar(typ types.Type) bool {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsString|types.IsNumeric|types.IsBoolean) != 0 && basic.Info()&types.IsComplex == 0
+}
+
+func isStruct(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Struct)
+	return ok
+}
+
+func typeString(pass *analysis.Pass, typ types.Type) string {
+	return types.TypeString(typ, types.RelativeTo(pass.Pkg))
+}
+
+func checkDuplicateNames(pass *analysis.Pass, st *types.Struct, key string) {
+	// seen maps serialised name to path of field, e.g. Base.ID for promoted field
+	seen := make(map[string]string)
+
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		for name, path := range serialNames(field, st.Tag(i), key, map[*types.Struct]bool{st: true}) {
+			if prev, ok := seen[name]; ok {
+				pass.Reportf(field.Pos(), "duplicate %s name %q: field %s conflicts with %s", key, name, path, prev)
+				continue
+			}
+			seen[name] = path
+		}
+	}
+}
+
+// serialNames returns names of field and fields promoted from it with their paths
+func serialNames(field *types.Var, tag, key string, visited map[*types.Struct]bool) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		value, _ := reflect.StructTag(tag).Lookup(key)
+		if value == "-" {
+			return
+		}
+		name, opts, _ := strings.Cut(value, ",")
+
+		if st := embeddedStruct(field.Type()); field.Embedded() && st != nil && promotesFields(key, name, opts) {
+			if visited[st] {
+				return
+			}
+			visited[st] = true
+
+			for i := range st.NumFields() {
+				for name, path := range serialNames(st.Field(i), st.Tag(i), key, visited) {
+					if !yield(name, field.Name()+"."+path) {
+						return
+					}
+				}
+			}
+			return
+		}
+
+		if !field.Exported() {
+			return
+		}
+		if name == "" {
+			name = defaultSerialName(field.Name())
+		}
+		yield(name, field.Name())
+	}
+}
+
+// promotesFields reports whether fields of embedded struct are serialised as fields of outer struct
+func promotesFields(key, name, opts string) bool {
+	switch key {
+	case "yaml":
+		return slices.Contains(strings.Split(opts, ","), "inline")
+	default:
+		return name == ""
+	}
+}
+
+func embeddedStruct(typ types.Type) *types.Struct {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	st, _ := typ.Underlying().(*types.Struct)
+	return st
+}
+
+// defaultSerialName returns name of untagged field used by yaml and sqlx encoders
+func defaultSerialName(fieldName string) string {
+	return strings.ToLower(fieldName)
+}
@@ -1,9 +1,10 @@
 package structtagcase
 
 import (
-	"flag"
+	"fmt"
 	"go/ast"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -12,10 +13,6 @@ import (
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-func init() {
-	flags.Var(&flagForceCasing, "force-casing", "force specific case to be used in struct tags: snake, camel, kebab")
-}
-
 const Name = "structtagcase"
 
 type stringCasing string
@@ -26,11 +23,12 @@ const (
 	casingCamel   stringCasing = "camel"
 	casingKebab   stringCasing = "kebab"
 	casingMixed   stringCasing = "mixed"
+	// casingScreamingSnake is upper snake case, e.g. DB_HOST
+	casingScreamingSnake stringCasing = "screaming_snake"
 )
 
 func (s *stringCasing) Set(v string) error {
-	switch stringCasing(v) {
-	case casingSnake, casingCamel, casingKebab:
+	if stringCasing(v).forcible() {
 		*s = stringCasing(v)
 	}
 	return nil
@@ -38,28 +36,160 @@ func (s *stringCasing) Set(v string) error {
 
 func (s stringCasing) String() string {
 	switch s {
-	case casingSnake, casingCamel, casingKebab, casingMixed:
+	case casingSnake, casingCamel, casingKebab, casingScreamingSnake, casingMixed:
 		return string(s)
 	default:
 		return "unknown"
 	}
 }
 
-var (
-	knownKeys = []string{"json", "bson", "xml", "yaml"}
+// forcible reports whether names can be required to be in casing
+func (s stringCasing) forcible() bool {
+	switch s {
+	case casingSnake, casingCamel, casingKebab, casingScreamingSnake:
+		return true
+	default:
+		return false
+	}
+}
+
+// tagKeys is a comma-separated list of struct tag keys
+type tagKeys []string
 
-	flags           flag.FlagSet
-	flagForceCasing stringCasing
-)
+func (k *tagKeys) Set(v string) error {
+	*k = nil
+	for _, key := range strings.Split(v, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			*k = append(*k, key)
+		}
+	}
+	return nil
+}
+
+func (k tagKeys) String() string {
+	return strings.Join(k, ",")
+}
+
+// casingPolicy maps struct tag key to casing, which names of the key must be in
+type casingPolicy map[string]stringCasing
+
+func (p casingPolicy) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		key, casing, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid casing policy %q: expected key=casing", item)
+		}
 
-var Analyzer = &analysis.Analyzer{
-	Name:  Name,
-	Doc:   `structtagcase checks that you use consistent name case in struct tags`,
-	Run:   run,
-	Flags: flags,
+		c := stringCasing(strings.TrimSpace(casing))
+		if !c.forcible() {
+			return fmt.Errorf("invalid casing policy %q: unknown casing %q", item, c)
+		}
+		p[strings.TrimSpace(key)] = c
+	}
+	return nil
 }
 
-func run(pass *analysis.Pass) (any, error) {
+func (p casingPolicy) String() string {
+	items := make([]string, 0, len(p))
+	for key, casing := range p {
+		items = append(items, key+"="+string(casing))
+	}
+	slices.Sort(items)
+	return strings.Join(items, ",")
+}
+
+// knownKeys are struct tag keys checked by default, other keys are checked with -keys or -casing
+var knownKeys = tagKeys{"json", "bson", "xml", "yaml"}
+
+// Options configures structtagcase analyzer. Flags of analyzer are initialized
+// with options and may override them.
+type Options struct {
+	// Name is a name of analyzer, Name by default. Instances registered in one checker must have distinct names.
+	Name string
+	// Keys are struct tag keys to check, default keys are checked if empty
+	Keys []string
+	// ForceCasing is a case required in all checked keys: snake, camel, kebab or screaming_snake
+	ForceCasing string
+	// Casing maps struct tag key to case required in it, takes precedence over ForceCasing
+	Casing map[string]string
+	// FieldNames enables reporting of tag names, which don't match names of their fields
+	FieldNames bool
+	// Package enables consistency of casing across package and its parent and child packages.
+	// Only such analyzer declares CasingFact and -package flag, so one of them may be registered in a checker.
+	Package bool
+}
+
+// Analyzer is a structtagcase analyzer with default options
+var Analyzer = New(Options{})
+
+// config holds settings of single analyzer instance
+type config struct {
+	keys        tagKeys
+	forceCasing stringCasing
+	casing      casingPolicy
+	fieldNames  bool
+	packageWide bool
+	// err is an error of invalid options reported on run
+	err error
+}
+
+// New returns structtagcase analyzer configured with options.
+// Each call returns independent analyzer with its own flags.
+func New(opts Options) *analysis.Analyzer {
+	cfg := &config{
+		keys:        slices.Clone(knownKeys),
+		casing:      casingPolicy{},
+		fieldNames:  opts.FieldNames,
+		packageWide: opts.Package,
+	}
+	if len(opts.Keys) > 0 {
+		cfg.keys = slices.Clone(opts.Keys)
+	}
+	if opts.ForceCasing != "" {
+		if !stringCasing(opts.ForceCasing).forcible() {
+			cfg.err = fmt.Errorf("invalid force casing %q", opts.ForceCasing)
+		}
+		cfg.forceCasing = stringCasing(opts.ForceCasing)
+	}
+	for key, casing := range opts.Casing {
+		if err := cfg.casing.Set(key + "=" + casing); err != nil {
+			cfg.err = err
+		}
+	}
+
+	name := Name
+	if opts.Name != "" {
+		name = opts.Name
+	}
+
+	a := &analysis.Analyzer{
+		Name: name,
+		Doc:  name + ` checks that you use consistent name case in struct tags`,
+		Run:  cfg.run,
+	}
+	a.Flags.Var(&cfg.forceCasing, "force-casing", "force specific case to be used in struct tags: snake, camel, kebab, screaming_snake")
+	a.Flags.Var(&cfg.keys, "keys", "comma-separated list of struct tag keys to check")
+	a.Flags.Var(cfg.casing, "casing", "comma-separated list of casing policies per struct tag key, e.g. json=camel,db=snake,env=screaming_snake")
+	a.Flags.BoolVar(&cfg.fieldNames, "field-names", cfg.fieldNames, "report tag names, which don't match names of their fields")
+
+	// fact type may be declared by single analyzer of checker, so only package-wide instance declares it
+	if opts.Package {
+		a.FactTypes = []analysis.Fact{new(CasingFact)}
+		a.Flags.BoolVar(&cfg.packageWide, "package", cfg.packageWide, "require casing used by most tags of package and casing of parent and child packages")
+	}
+
+	return a
+}
+
+func (cfg *config) run(pass *analysis.Pass) (any, error) {
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
+
 	ins := inspector.New(pass.Files)
 
 	// filter only function calls.
@@ -67,47 +197,75 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.StructType)(nil),
 	}
 
+	var structs []*ast.StructType
 	ins.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
 		// do not fall into leaf twice
 		if !push {
 			return false
 		}
 
-		checkTagsCasing(pass, n.(*ast.StructType), flagForceCasing)
+		structs = append(structs, n.(*ast.StructType))
 		return true
 	})
 
+	var pkgCasings map[string]packageCasing
+	if cfg.packageWide {
+		pkgCasings = cfg.packageCasings(pass, structs)
+	}
+
+	for _, node := range structs {
+		checkTagsCasing(pass, node, cfg, pkgCasings)
+		if cfg.fieldNames {
+			checkFieldNames(pass, node, cfg)
+		}
+	}
+
 	return nil, nil
 }
 
-func checkTagsCasing(pass *analysis.Pass, node *ast.StructType, forcedCase stringCasing) {
-	for _, tagKey := range knownKeys {
+// checkedKeys returns keys to check: configured ones and ones having casing policy
+func (cfg *config) checkedKeys() []string {
+	checked := slices.Clone(cfg.keys)
+	var extra []string
+	for key := range cfg.casing {
+		if !slices.Contains(checked, key) {
+			extra = append(extra, key)
+		}
+	}
+	slices.Sort(extra)
+	return append(checked, extra...)
+}
+
+// expectedCasing returns case required in key, casing policy of key takes precedence over forced casing
+func (cfg *config) expectedCasing(key string) stringCasing {
+	if casing, ok := cfg.casing[key]; ok {
+		return casing
+	}
+	return cfg.forceCasing
+}
+
+func checkTagsCasing(pass *analysis.Pass, node *ast.StructType, cfg *config, pkgCasings map[string]packageCasing) {
+	for _, tagKey := range cfg.checkedKeys() {
 		// start casing for struct key
 		keyCasing := casingUnknown
+		expectedCase, reason := cfg.expectedCasing(tagKey), ""
+		if pc, ok := pkgCasings[tagKey]; ok && expectedCase == casingUnknown {
+			expectedCase, reason = pc.casing, pc.reason()
+		}
 
 		for _, field := range node.Fields.List {
-			if field.Tag == nil {
-				continue
-			}
-
-			rawTag, _ := strconv.Unquote(field.Tag.Value)
-			if rawTag == "" {
-				continue
-			}
-
-			structTag, ok := reflect.StructTag(rawTag).Lookup(tagKey)
-			if !ok {
-				continue
-			}
-
-			name := extractTagName(structTag)
+			name := fieldTagName(field, tagKey)
 			if name == "" || name == "-" {
 				continue
 			}
 
 			tagCasing := detectCasing(name)
-			if forcedCase != casingUnknown && tagCasing != casingUnknown && tagCasing != forcedCase {
-				pass.Reportf(field.End(), "%s struct tag must be in %s case: %s", tagKey, forcedCase, name)
+			if expectedCase != casingUnknown && !matchesCasing(name, tagCasing, expectedCase) {
+				pass.Report(analysis.Diagnostic{
+					Pos:            field.End(),
+					Message:        fmt.Sprintf("%s struct tag must be in %s case%s: %s", tagKey, expectedCase, reason, name),
+					SuggestedFixes: suggestRename(field, tagKey, name, convertCasing(name, expectedCase)),
+				})
 				continue
 			}
 
@@ -123,13 +281,65 @@ func checkTagsCasing(pass *analysis.Pass, node *ast.StructType, forcedCase strin
 			}
 
 			if tagCasing != casingUnknown && tagCasing != keyCasing {
-				pass.Reportf(field.End(), "inconsistent text case in %s struct tag: %s", tagKey, name)
+				pass.Report(analysis.Diagnostic{
+					Pos:            field.End(),
+					Message:        fmt.Sprintf("inconsistent text case in %s struct tag: %s", tagKey, name),
+					SuggestedFixes: suggestRename(field, tagKey, name, convertCasing(name, keyCasing)),
+				})
 			}
 		}
 	}
 }
 
-func extractTagName(value string) string {
+// matchesCasing reports whether name with detected casing fits expected one,
+// single words are checked by letter case only
+func matchesCasing(name string, detected, expected stringCasing) bool {
+	if detected != casingUnknown {
+		return detected == expected
+	}
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if expected == casingScreamingSnake {
+			return !strings.ContainsFunc(name, unicode.IsLower)
+		}
+		return !unicode.IsUpper(r)
+	}
+	return true
+}
+
+// fieldTagName returns name of key in field tag, empty if field has no such key
+func fieldTagName(field *ast.Field, key string) string {
+	if field.Tag == nil {
+		return ""
+	}
+
+	rawTag, _ := strconv.Unquote(field.Tag.Value)
+	if rawTag == "" {
+		return ""
+	}
+
+	structTag, ok := reflect.StructTag(rawTag).Lookup(key)
+	if !ok {
+		return ""
+	}
+
+	return extractTagName(key, structTag)
+}
+
+func extractTagName(key, value string) string {
+	// protobuf tags start with wire type and keep name in option, e.g. "bytes,1,opt,name=user_id,proto3"
+	if key == "protobuf" {
+		for _, opt := range strings.Split(value, ",") {
+			if name, ok := strings.CutPrefix(opt, "name="); ok {
+				return name
+			}
+		}
+		return ""
+	}
+
 	name := value
 	idx := strings.Index(value, ",")
 	if idx != -1 {
@@ -171,6 +381,10 @@ func detectCasing(value string) stringCasing {
 	if hasLowercase && hasUppercase && (hasUnderscore || hasDash) {
 		return casingMixed
 	}
+	// screaming snake
+	if hasUnderscore && hasUppercase && !hasLowercase {
+		return casingScreamingSnake
+	}
 	// snake
 	if hasUnderscore && (hasLowercase || hasUppercase) {
 		return casingSnake
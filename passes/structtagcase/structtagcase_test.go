@@ -1,9 +1,12 @@
 package structtagcase
 
 import (
+	"flag"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -13,40 +16,130 @@ func Test(t *testing.T) {
 }
 
 func TestForceCasing(t *testing.T) {
-	t.Run("no_flag", func(t *testing.T) {
-		testdata := analysistest.TestData()
-		analysistest.Run(t, testdata, Analyzer, "a")
-	})
+	testCases := []struct {
+		name   string
+		casing string
+		pkg    string
+	}{
+		{"no_flag", "", "a"},
+		{"snake", "snake", "force_snake"},
+		{"camel", "camel", "force_camel"},
+		{"kebab", "kebab", "force_kebab"},
+	}
 
-	t.Run("snake", func(t *testing.T) {
-		flagForceCasing = casingSnake
-		defer func() {
-			flagForceCasing = casingUnknown
-		}()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
 
-		testdata := analysistest.TestData()
-		analysistest.Run(t, testdata, Analyzer, "force_snake")
-	})
+			testdata := analysistest.TestData()
+			analysistest.Run(t, testdata, New(Options{ForceCasing: tc.casing}), tc.pkg)
+		})
+	}
+}
+
+func TestForceCasingFlag(t *testing.T) {
+	analyzer := New(Options{})
+	require.NoError(t, analyzer.Flags.Set("force-casing", "snake"))
 
-	t.Run("camel", func(t *testing.T) {
-		flagForceCasing = casingCamel
-		defer func() {
-			flagForceCasing = casingUnknown
-		}()
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "force_snake")
+	// default instance isn't affected by flags of other instances
+	analysistest.Run(t, testdata, Analyzer, "a")
+}
 
-		testdata := analysistest.TestData()
-		analysistest.Run(t, testdata, Analyzer, "force_camel")
+func TestName(t *testing.T) {
+	apiAnalyzer := New(Options{Name: "apitagcase", ForceCasing: "snake"})
+	assert.Equal(t, "apitagcase", apiAnalyzer.Name)
+	assert.Equal(t, Name, New(Options{}).Name)
+
+	// checkers register flags of analyzers prefixed with their names
+	flags := flag.NewFlagSet("checker", flag.ContinueOnError)
+	require.NotPanics(t, func() {
+		for _, a := range []*analysis.Analyzer{Analyzer, apiAnalyzer} {
+			a.Flags.VisitAll(func(f *flag.Flag) {
+				flags.Var(f.Value, a.Name+"."+f.Name, f.Usage)
+			})
+		}
 	})
+	require.NoError(t, flags.Parse([]string{"-apitagcase.force-casing=snake"}))
 
-	t.Run("kebab", func(t *testing.T) {
-		flagForceCasing = casingKebab
-		defer func() {
-			flagForceCasing = casingUnknown
-		}()
+	pkgAnalyzer := New(Options{Name: "structtagpkgcase", Package: true})
+	require.NoError(t, analysis.Validate([]*analysis.Analyzer{Analyzer, apiAnalyzer, pkgAnalyzer}))
 
-		testdata := analysistest.TestData()
-		analysistest.Run(t, testdata, Analyzer, "force_kebab")
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, apiAnalyzer, "force_snake")
+	analysistest.Run(t, testdata, Analyzer, "a")
+}
+
+func TestCasingPolicy(t *testing.T) {
+	analyzer := New(Options{
+		Keys:   []string{"mapstructure", "toml", "protobuf", "form", "query"},
+		Casing: map[string]string{"json": "camel", "db": "snake", "env": "screaming_snake"},
 	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "policy")
+}
+
+func TestInvalidOptions(t *testing.T) {
+	for _, opts := range []Options{
+		{ForceCasing: "pascal"},
+		{Casing: map[string]string{"json": "mixed"}},
+	} {
+		analyzer := New(opts)
+		_, err := analyzer.Run(nil)
+		assert.Error(t, err)
+	}
+}
+
+func TestCasingPolicySet(t *testing.T) {
+	policy := casingPolicy{}
+	require.NoError(t, policy.Set("json=camel,db=snake"))
+	assert.Equal(t, "db=snake,json=camel", policy.String())
+
+	assert.Error(t, policy.Set("json"))
+	assert.Error(t, policy.Set("json=pascal"))
+	assert.Error(t, policy.Set("json=mixed"))
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, New(Options{Keys: []string{"json", "yaml", "xml", "protobuf"}}), "fixes")
+
+	analyzer := New(Options{Casing: map[string]string{"json": "camel", "env": "screaming_snake"}})
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "force_fixes")
+}
+
+func TestFieldNames(t *testing.T) {
+	testdata := analysistest.TestData()
+	analyzer := New(Options{Keys: []string{"json", "yaml", "db", "env"}, FieldNames: true})
+	analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "drift")
+}
+
+func TestConvertCasing(t *testing.T) {
+	testCases := []struct {
+		name     string
+		casing   stringCasing
+		expected string
+	}{
+		{"UserID", casingSnake, "user_id"},
+		{"userID", casingKebab, "user-id"},
+		{"HTTPServer", casingSnake, "http_server"},
+		{"relatedURLs", casingSnake, "related_urls"},
+		{"UserIDs", casingScreamingSnake, "USER_IDS"},
+		{"address2Line", casingSnake, "address2_line"},
+		{"user_id", casingCamel, "userId"},
+		{"DB_HOST", casingCamel, "dbHost"},
+		{"api-key", casingScreamingSnake, "API_KEY"},
+		{"name", casingCamel, "name"},
+		{"userName", casingUnknown, "userName"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, convertCasing(tc.name, tc.casing))
+		})
+	}
 }
 
 func TestDetectCasing(t *testing.T) {
@@ -59,6 +152,7 @@ func TestDetectCasing(t *testing.T) {
 		{"camel_case", "ololoTrololo", casingCamel},
 		{"unknown_case", "ololo", casingUnknown},
 		{"kebab_case", "ololo-trololo", casingKebab},
+		{"screaming_snake_case", "OLOLO_TROLOLO", casingScreamingSnake},
 		{"some_strange_thing", "ololo_trololoShimbaBoomba", casingMixed},
 		{"some_strange_thing", "ololo-trololoShimbaBoomba", casingMixed},
 		{"some_strange_thing", "ololo-trololo-shimbaBoomba", casingMixed},
@@ -71,3 +165,19 @@ func TestDetectCasing(t *testing.T) {
 		})
 	}
 }
+
+func TestTagAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, TagAnalyzer, "structtag")
+}
+
+func TestPackageCasing(t *testing.T) {
+	assert.Empty(t, Analyzer.FactTypes)
+	assert.Nil(t, Analyzer.Flags.Lookup("package"))
+
+	analyzer := New(Options{Package: true})
+	assert.NotNil(t, analyzer.Flags.Lookup("package"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "pkgcase/...")
+}
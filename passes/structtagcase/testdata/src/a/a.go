@@ -51,3 +51,10 @@ type AcceptableSnakeCasing struct {
 	SocialSecurityNumber string `bson:"ssn"`
 	LikedGenres          string `bson:"liked_genres"`
 }
+
+// Keys other than json, bson, xml and yaml aren't checked by default
+type Config struct {
+	Host      string `db:"host" mapstructure:"host"`
+	UserName  string `db:"user_name" mapstructure:"userName"`
+	RetryTime int    `db:"retryTime" mapstructure:"retry-time"`
+}
@@ -0,0 +1,25 @@
+package drift
+
+import "time"
+
+type Event struct {
+	ID        string    `json:"id" db:"id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	CreatedAt time.Time `json:"updated_at" db:"created_at"` // want `json struct tag doesn't match field name CreatedAt: updated_at`
+	Title     string    `json:"name,omitempty" db:"title"`  // want `json struct tag doesn't match field name Title: name`
+	// Kind is named type in API for historical reasons
+	//structtagcase:rename
+	Kind string `json:"type" db:"type"`
+	Body string `json:"text" db:"body"` //structtagcase:rename json
+	Tags string `json:"labels" db:"labels"` //structtagcase:rename json // want `db struct tag doesn't match field name Tags: labels`
+	Skip string `json:"-"`
+	A, B string `json:"c"`
+	// unexported fields aren't serialised
+	secret string `json:"token"`
+	_      int    `json:"padding"`
+	time.Location
+}
+
+type Config struct {
+	RetryCount int `env:"RETRIES"` // want `env struct tag doesn't match field name RetryCount: RETRIES`
+}
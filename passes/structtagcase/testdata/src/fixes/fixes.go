@@ -0,0 +1,19 @@
+package fixes
+
+type User struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name,omitempty" yaml:"first_name"`
+	UserID    string `json:"UserID,omitempty" yaml:"userID"`           // want `inconsistent text case in json struct tag: UserID` `inconsistent text case in yaml struct tag: userID`
+	ServerURL string `json:"HTTPServer,omitempty" yaml:"server_url"` // want `inconsistent text case in json struct tag: HTTPServer`
+	Links     string `json:"relatedURLs" xml:"links"`                // want `inconsistent text case in json struct tag: relatedURLs`
+}
+
+type Escaped struct {
+	CreatedAt string "json:\"created_at\""
+	UpdatedAt string "json:\"updatedAt\" yaml:\"updated_at\"" // want `inconsistent text case in json struct tag: updatedAt`
+}
+
+type Message struct {
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`
+	CreatedAt int64  `protobuf:"varint,2,opt,name=createdAt,json=createdAt,proto3"` // want `inconsistent text case in protobuf struct tag: createdAt`
+}
@@ -0,0 +1,7 @@
+package force_fixes
+
+type Config struct {
+	DBHost   string `json:"db_host" env:"dbHost"`      // want `json struct tag must be in camel case: db_host` `env struct tag must be in screaming_snake case: dbHost`
+	APIKey   string `json:"api-key,omitempty" env:"API_KEY"` // want `json struct tag must be in camel case: api-key`
+	UserName string `json:"userName" env:"user_name"`  // want `env struct tag must be in screaming_snake case: user_name`
+}
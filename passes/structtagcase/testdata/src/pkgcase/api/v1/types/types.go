@@ -0,0 +1,12 @@
+package types // want package:"casing\\(json=snake\\)"
+
+type User struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+type Group struct {
+	GroupName string `json:"groupName"` // want `json struct tag must be in snake case used in package: groupName`
+	OwnerID   string `json:"owner_id"`
+}
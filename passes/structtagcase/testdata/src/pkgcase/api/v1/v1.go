@@ -0,0 +1,16 @@
+package v1 // want package:"casing\\(json=snake,yaml=snake\\)"
+
+import "pkgcase/api/v1/types"
+
+type CreateUserRequest struct {
+	User      types.User `json:"user"`
+	RequestID string     `json:"requestId"`  // want `json struct tag must be in snake case used in package pkgcase/api/v1/types: requestId`
+	DryRun    bool       `json:"dryRun"`     // want `json struct tag must be in snake case used in package pkgcase/api/v1/types: dryRun`
+	TraceID   string     `yaml:"trace_id"`
+}
+
+type Settings struct {
+	MaxItems  int `yaml:"maxItems"` // want `yaml struct tag must be in snake case used in package: maxItems`
+	PageSize  int `yaml:"page_size"`
+	SortOrder int `yaml:"sort_order"`
+}
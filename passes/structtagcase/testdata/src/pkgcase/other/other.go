@@ -0,0 +1,10 @@
+package other // want package:"casing\\(json=camel\\)"
+
+import "pkgcase/api/v1/types"
+
+type Response struct {
+	Users      []types.User `json:"users"`
+	NextCursor string       `json:"nextCursor"`
+	TotalCount int          `json:"totalCount"`
+	HasMore    bool         `json:"has_more"` // want `json struct tag must be in camel case used in package: has_more`
+}
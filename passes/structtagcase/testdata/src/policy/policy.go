@@ -0,0 +1,32 @@
+package policy
+
+type Config struct {
+	Host     string `json:"host" db:"host" env:"HOST"`
+	Port     int    `json:"port" db:"port" env:"PORT"`
+	UserName string `json:"userName" db:"user_name" env:"USER_NAME"`
+	Password string `json:"password_hash" db:"passwordHash" env:"db_password"` // want `json struct tag must be in camel case: password_hash` `db struct tag must be in snake case: passwordHash` `env struct tag must be in screaming_snake case: db_password`
+	Timeout  int    `json:"timeout,omitempty" mapstructure:"timeout" toml:"timeout"`
+}
+
+type Single struct {
+	Host string `env:"host" json:"ID"`             // want `env struct tag must be in screaming_snake case: host` `json struct tag must be in camel case: ID`
+	Port int    `env:"PORT" json:"port" db:"Port"` // want `db struct tag must be in snake case: Port`
+	Addr string `env:"ADDR2" json:"addr2" db:"v2"`
+}
+
+type Settings struct {
+	MaxConns    int    `mapstructure:"max_conns" toml:"max-conns"`
+	IdleTimeout int    `mapstructure:"idleTimeout" toml:"idle_timeout"` // want `inconsistent text case in mapstructure struct tag: idleTimeout` `inconsistent text case in toml struct tag: idle_timeout`
+	SortOrder   string `form:"sort_order" query:"sort_order"`
+	PageSize    int    `form:"pageSize" query:"page-size"` // want `inconsistent text case in form struct tag: pageSize` `inconsistent text case in query struct tag: page-size`
+}
+
+type Message struct {
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`
+	CreatedAt int64  `protobuf:"varint,2,opt,name=createdAt,json=createdAt,proto3"` // want `inconsistent text case in protobuf struct tag: createdAt`
+}
+
+type Unchecked struct {
+	FirstName string `yaml:"first_name"`
+	LastName  string `yaml:"lastName"`
+}
@@ -0,0 +1,67 @@
+package structtag
+
+import "time"
+
+type Options struct {
+	Name      string            `json:"name,omitmepty"` // want `unknown option "omitmepty" in json struct tag of field Name`
+	Count     int               `json:"count,string"`
+	Ratio     *float64          `json:"ratio,string,omitempty"`
+	Labels    map[string]string `json:"labels,string"`        // want `json option "string" has no effect on field Labels of non-scalar type map\[string\]string`
+	CreatedAt time.Time         `json:"created_at,omitempty"` // want `json option "omitempty" has no effect on field CreatedAt of struct type time.Time, use pointer instead`
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
+	Deleted   Flag              `json:"deleted,omitempty,string"`
+	Config    string            `yaml:"config,inlined"` // want `unknown option "inlined" in yaml struct tag of field Config`
+	Ignored   string            `json:"-,"`
+}
+
+type Flag bool
+
+type Base struct {
+	ID        string `json:"id" db:"id"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+	internal  string
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by" yaml:"created_by"`
+	Version   int    `yaml:"version"`
+}
+
+type User struct {
+	Base
+	*Audit `yaml:",inline"`
+	ID     string `json:"id"`         // want `duplicate db name "id": field ID conflicts with Base.ID`
+	UserID string `db:"id"`           // want `duplicate db name "id": field UserID conflicts with Base.ID`
+	Author string `yaml:"created_by"` // want `duplicate yaml name "created_by": field Author conflicts with Audit.CreatedBy`
+	Rev    int    `yaml:"version"`    // want `duplicate yaml name "version": field Rev conflicts with Audit.Version`
+	Name   string `json:"name" yaml:"name"`
+	Title  string `yaml:"name"` // want `duplicate yaml name "name": field Title conflicts with Name`
+	Skip   string `json:"-" db:"-"`
+	Hidden string `yaml:"-"`
+	Email  string
+	Mail   string `db:"email"` // want `duplicate db name "email": field Mail conflicts with Email`
+	secret string `db:"id"`
+}
+
+type Nested struct {
+	Base `json:"base"`
+	ID   string `json:"id"` // want `duplicate db name "id": field ID conflicts with Base.ID`
+}
+
+type Left struct {
+	Key string
+}
+
+type Right struct {
+	Key string
+}
+
+type Composite struct {
+	Left
+	Right // want `duplicate db name "key": field Right.Key conflicts with Left.Key`
+}
+
+type List struct {
+	*List
+	Items []string `json:"items"`
+}